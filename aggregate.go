@@ -0,0 +1,399 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AggregationMethod is the method used to combine the rates from multiple providers
+type AggregationMethod uint8
+
+// AggregationMethod constants for the different ways to combine rates.
+// Leave the start and last constants in place
+const (
+	_ AggregationMethod = iota // 0
+
+	AggregationMedian       // 1
+	AggregationMean         // 2
+	AggregationWeightedMean // 3
+	AggregationVWAP         // 4 (weighted by the 24 hour volume of each provider, equal weights if a provider has no volume)
+	aggregationLast         // 5
+)
+
+// IsValid tests if the aggregation method is valid or not
+func (a AggregationMethod) IsValid() bool {
+	return a >= AggregationMedian && a < aggregationLast
+}
+
+// Name will return the display name for the given aggregation method
+func (a AggregationMethod) Name() string {
+	switch a {
+	case AggregationMedian:
+		return "median"
+	case AggregationMean:
+		return "mean"
+	case AggregationWeightedMean:
+		return "weighted_mean"
+	case AggregationVWAP:
+		return "vwap"
+	case aggregationLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// ErrQuorumNotMet is returned (wrapped in a QuorumError) when not enough providers agree on a rate
+var ErrQuorumNotMet = errors.New("quorum of agreeing providers not met")
+
+// QuorumError is returned when the consensus rules (ClientOptions) cannot be met
+type QuorumError struct {
+	Agreeing int             `json:"agreeing"` // Number of providers that agreed
+	Errors   ProviderErrors  `json:"errors"`   // Providers that failed
+	Failed   int             `json:"failed"`   // Number of providers that failed
+	Rejected []*ProviderRate `json:"rejected"` // Providers that diverged too far from the median
+	Required int             `json:"required"` // Minimum number of agreeing providers
+}
+
+// Error will return the error message
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf(
+		"quorum not met: %d of %d required providers agreed (%d rejected, %d failed)",
+		e.Agreeing, e.Required, len(e.Rejected), e.Failed,
+	)
+}
+
+// Is will match the ErrQuorumNotMet sentinel error
+func (e *QuorumError) Is(target error) bool {
+	return target == ErrQuorumNotMet
+}
+
+// Unwrap will return the provider failures (nil if no provider failed)
+func (e *QuorumError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors
+}
+
+// ProviderRate is the rate returned by a single provider
+type ProviderRate struct {
+	Cache     CacheStatus `json:"cache"`      // Cache status of the rate
+	FetchedAt time.Time   `json:"fetched_at"` // When the rate was fetched from the provider
+	Name      string      `json:"name"`       // Display name of the provider
+	Provider  Provider    `json:"provider"`   // Provider constant (ProviderCustom if not built-in)
+	QuotedAt  time.Time   `json:"quoted_at"`  // When the provider last updated the rate (zero if unknown)
+	Rate      float64     `json:"rate"`       // Rate returned by the provider
+	Volume24h float64     `json:"volume_24h"` // 24 hour volume in the currency (zero if unknown), used for AggregationVWAP
+	Weight    float64     `json:"weight"`     // Weight used for AggregationWeightedMean
+}
+
+// AggregatedRate is the result of combining the rates from all providers
+type AggregatedRate struct {
+	Contributors []*ProviderRate   `json:"contributors"` // Providers that returned a rate
+	Currency     Currency          `json:"currency"`     // Currency of the rate
+	Errors       ProviderErrors    `json:"errors"`       // Providers that failed
+	Failed       int               `json:"failed"`       // Number of providers that failed
+	Method       AggregationMethod `json:"method"`       // Method used to combine the rates
+	Rate         float64           `json:"rate"`         // Combined rate
+	Rejected     []*ProviderRate   `json:"rejected"`     // Providers that diverged too far from the median
+	Spread       float64           `json:"spread"`       // Difference between the highest and lowest rate
+}
+
+// cacheStatus will return the combined cache status of the contributors
+func (a *AggregatedRate) cacheStatus() CacheStatus {
+	statuses := make([]CacheStatus, 0, len(a.Contributors))
+	for _, contributor := range a.Contributors {
+		statuses = append(statuses, contributor.Cache)
+	}
+	return combineCacheStatus(statuses...)
+}
+
+// rateResult will return the aggregated rate as a RateResult
+// (the provider is the first contributor, and the times are the oldest of the contributors)
+func (a *AggregatedRate) rateResult() *RateResult {
+	result := &RateResult{
+		Cache:        a.cacheStatus(),
+		Currency:     a.Currency,
+		Errors:       a.Errors,
+		Provider:     a.Contributors[0].Provider,
+		ProviderName: a.Contributors[0].Name,
+		Rate:         decimal.NewFromFloat(a.Rate),
+	}
+	for _, contributor := range a.Contributors {
+		if result.FetchedAt.IsZero() || contributor.FetchedAt.Before(result.FetchedAt) {
+			result.FetchedAt = contributor.FetchedAt
+		}
+		if !contributor.QuotedAt.IsZero() &&
+			(result.QuotedAt.IsZero() || contributor.QuotedAt.Before(result.QuotedAt)) {
+			result.QuotedAt = contributor.QuotedAt
+		}
+	}
+	return result
+}
+
+// AggregatedConversion is the result of converting an amount using the aggregated rate
+type AggregatedConversion struct {
+	*AggregatedRate
+	Amount   float64 `json:"amount"`   // Amount of the currency that was converted
+	Satoshis int64   `json:"satoshis"` // Satoshis for the given amount
+}
+
+// GetAggregatedRate will get a BSV->Currency rate from every provider (concurrently)
+// and combine the results using the given method.
+//
+// Providers that diverge from the median by more than MaxDeviationPercent are rejected,
+// and a QuorumError is returned if fewer than MinimumQuorum providers agree
+func (c *Client) GetAggregatedRate(ctx context.Context, currency Currency,
+	method AggregationMethod) (result *AggregatedRate, err error) {
+
+	// Trace the lookup
+	ctx, span := c.tracer.Start(ctx, spanAggregatedRate,
+		Attribute{Key: "currency", Value: currency.Name()},
+		Attribute{Key: "method", Value: method.Name()},
+	)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(Attribute{Key: "contributors", Value: len(result.Contributors)})
+		}
+		span.End(err)
+	}()
+
+	// Check if currency is accepted by any provider
+	if !currency.IsAccepted() {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not accepted by all providers at this time", currency.Name())
+		return
+	}
+
+	// Check the method
+	if !method.IsValid() {
+		err = fmt.Errorf("aggregation method [%d] is not valid", method)
+		return
+	}
+
+	// Get all the rates
+	rates, errs := c.getProviderRates(ctx, currency)
+	failed := len(errs)
+	if len(rates) == 0 && failed > 0 && c.options.useConsensus() {
+		err = &QuorumError{Errors: errs, Failed: failed, Required: c.options.quorum()}
+		return
+	} else if len(rates) == 0 && failed > 0 {
+		err = fmt.Errorf("no rates returned from %d providers: %w", failed, errs)
+		return
+	} else if len(rates) == 0 {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not supported by any provider", currency.Name())
+		return
+	}
+
+	// Reject any outliers and check the quorum
+	var rejected []*ProviderRate
+	rates, rejected = rejectOutliers(rates, c.options.MaxDeviationPercent)
+	if required := c.options.quorum(); len(rates) < required {
+		err = &QuorumError{
+			Agreeing: len(rates),
+			Errors:   errs,
+			Failed:   failed,
+			Rejected: rejected,
+			Required: required,
+		}
+		return
+	}
+
+	// Combine the rates
+	result = &AggregatedRate{
+		Contributors: rates,
+		Currency:     currency,
+		Errors:       errs,
+		Failed:       failed,
+		Method:       method,
+		Rejected:     rejected,
+		Spread:       rateSpread(rates),
+	}
+	switch method {
+	case AggregationMedian:
+		result.Rate = medianRate(rates)
+	case AggregationMean:
+		result.Rate = meanRate(rates, false)
+	case AggregationWeightedMean:
+		result.Rate = meanRate(rates, true)
+	case AggregationVWAP:
+		result.Rate = vwapRate(rates)
+	case aggregationLast:
+	}
+
+	return
+}
+
+// GetAggregatedConversion will get the satoshi amount for the given currency + amount
+// using the aggregated rate from every provider
+func (c *Client) GetAggregatedConversion(ctx context.Context, currency Currency, amount float64,
+	method AggregationMethod) (result *AggregatedConversion, err error) {
+
+	// Get the aggregated rate
+	var rate *AggregatedRate
+	if rate, err = c.GetAggregatedRate(ctx, currency, method); err != nil {
+		return
+	}
+
+	// Convert using the rate
+	result = &AggregatedConversion{AggregatedRate: rate, Amount: amount}
+	if result.Satoshis, err = ConvertPriceToSatoshis(rate.Rate, amount); err != nil {
+		result = nil
+	}
+	return
+}
+
+// getProviderRates will get the rate from every provider that supports the currency (concurrently)
+// and return the successful rates and the failures (both in provider order)
+func (c *Client) getProviderRates(ctx context.Context, currency Currency) (rates []*ProviderRate,
+	errs ProviderErrors) {
+
+	// Fire all the requests
+	providers := c.providersFor(currency)
+	results := make([]*ProviderRate, len(providers))
+	failures := make([]*ProviderError, len(providers))
+	var wg sync.WaitGroup
+	for index, provider := range providers {
+		wg.Add(1)
+		go func(index int, provider RateProvider) {
+			defer wg.Done()
+			start := time.Now()
+			c.logAttempt(ctx, "rate", provider, currency, index+1)
+			attemptCtx, attemptSpan := c.startAttempt(ctx, provider, currency, index)
+			quote, status, err := c.getProviderQuote(attemptCtx, provider, currency)
+			endAttempt(attemptSpan, status, err)
+			if err != nil {
+				failures[index] = c.providerFailed(ctx, "rate", provider, currency, err, time.Since(start), false)
+				return
+			}
+			results[index] = &ProviderRate{
+				Cache:     status,
+				FetchedAt: quote.FetchedAt,
+				Name:      provider.Name(),
+				Provider:  providerType(provider),
+				QuotedAt:  quote.QuotedAt,
+				Rate:      quote.Rate,
+				Volume24h: quote.Volume24h,
+				Weight:    c.providerWeight(provider.Name()),
+			}
+		}(index, provider)
+	}
+	wg.Wait()
+
+	// Collect the results
+	for index, result := range results {
+		if result == nil {
+			errs = append(errs, failures[index])
+			continue
+		}
+		rates = append(rates, result)
+	}
+	return
+}
+
+// providerWeight will return the configured weight for the provider (defaults to 1)
+func (c *Client) providerWeight(name string) float64 {
+	if weight, ok := c.options.ProviderWeights[name]; ok && weight >= 0 {
+		return weight
+	}
+	return 1
+}
+
+// rejectOutliers will split the rates into those within the max deviation (percent)
+// of the median and those that diverge (a max deviation of zero disables the check).
+//
+// The median of two rates is their mean, so both would always agree or diverge. With only
+// two rates, the first (the provider tried first) is the reference and the other is checked against it
+func rejectOutliers(rates []*ProviderRate, maxDeviationPercent float64) (agreeing, rejected []*ProviderRate) {
+	if maxDeviationPercent <= 0 {
+		return rates, nil
+	}
+	reference := medianRate(rates)
+	if len(rates) == 2 {
+		reference = rates[0].Rate
+	}
+	for _, rate := range rates {
+		if math.Abs(rate.Rate-reference)/reference*100 > maxDeviationPercent {
+			rejected = append(rejected, rate)
+			continue
+		}
+		agreeing = append(agreeing, rate)
+	}
+	return
+}
+
+// medianRate will return the median of the rates
+func medianRate(rates []*ProviderRate) float64 {
+	values := make([]float64, 0, len(rates))
+	for _, rate := range rates {
+		values = append(values, rate.Rate)
+	}
+	sort.Float64s(values)
+
+	// Even number of rates uses the mean of the middle two
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		median, _ := decimal.NewFromFloat(values[middle-1]).Add(
+			decimal.NewFromFloat(values[middle]),
+		).Div(decimal.NewFromInt(2)).Float64()
+		return median
+	}
+	return values[middle]
+}
+
+// meanRate will return the mean (or weighted mean) of the rates.
+// If all the weights are zero, the rates are weighted equally
+func meanRate(rates []*ProviderRate, weighted bool) float64 {
+	total := decimal.Zero
+	weights := decimal.Zero
+	for _, rate := range rates {
+		weight := decimal.NewFromInt(1)
+		if weighted {
+			weight = decimal.NewFromFloat(rate.Weight)
+		}
+		total = total.Add(decimal.NewFromFloat(rate.Rate).Mul(weight))
+		weights = weights.Add(weight)
+	}
+	if weights.IsZero() {
+		return meanRate(rates, false)
+	}
+	mean, _ := total.Div(weights).Float64()
+	return mean
+}
+
+// vwapRate will return the volume weighted average of the rates (VWAP).
+// If any provider has no volume data, the rates are weighted equally (the mean)
+func vwapRate(rates []*ProviderRate) float64 {
+	total, volumes := decimal.Zero, decimal.Zero
+	for _, rate := range rates {
+		if rate.Volume24h <= 0 {
+			return meanRate(rates, false)
+		}
+		volume := decimal.NewFromFloat(rate.Volume24h)
+		total = total.Add(decimal.NewFromFloat(rate.Rate).Mul(volume))
+		volumes = volumes.Add(volume)
+	}
+	vwap, _ := total.Div(volumes).Round(8).Float64()
+	return vwap
+}
+
+// rateSpread will return the difference between the highest and lowest rate
+func rateSpread(rates []*ProviderRate) float64 {
+	low, high := rates[0].Rate, rates[0].Rate
+	for _, rate := range rates[1:] {
+		if rate.Rate < low {
+			low = rate.Rate
+		} else if rate.Rate > high {
+			high = rate.Rate
+		}
+	}
+	spread, _ := decimal.NewFromFloat(high).Sub(decimal.NewFromFloat(low)).Float64()
+	return spread
+}
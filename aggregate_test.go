@@ -0,0 +1,397 @@
+package bsvrates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAggregationMethod_IsValid will test the method IsValid()
+func TestAggregationMethod_IsValid(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		testCase      string
+		method        AggregationMethod
+		expectedValid bool
+		expectedName  string
+	}{
+		{"method 0", 0, false, ""},
+		{"AggregationMedian", AggregationMedian, true, "median"},
+		{"AggregationMean", AggregationMean, true, "mean"},
+		{"AggregationWeightedMean", AggregationWeightedMean, true, "weighted_mean"},
+		{"AggregationVWAP", AggregationVWAP, true, "vwap"},
+		{"aggregationLast", aggregationLast, false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.testCase, func(t *testing.T) {
+			assert.Equal(t, test.expectedValid, test.method.IsValid())
+			assert.Equal(t, test.expectedName, test.method.Name())
+		})
+	}
+}
+
+// TestClient_GetAggregatedRate will test the method GetAggregatedRate()
+func TestClient_GetAggregatedRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("median - default providers", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, 158.75207624, result.Rate)
+		assert.Equal(t, 2, len(result.Contributors))
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, 0.51584752, result.Spread)
+		assert.Equal(t, ProviderCoinPaprika, result.Contributors[0].Provider)
+		assert.Equal(t, ProviderWhatsOnChain, result.Contributors[1].Provider)
+	})
+
+	t.Run("median - odd number of providers", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 100},
+			&mockRateProvider{name: "c", rate: 160},
+		), nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), result.Rate)
+		assert.Equal(t, float64(60), result.Spread)
+	})
+
+	t.Run("mean - with a failed provider", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b"},
+			&mockRateProvider{name: "c", rate: 160},
+		), nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMean)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(155), result.Rate)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 1, len(result.Errors))
+		assert.Equal(t, "b", result.Errors[0].Name)
+		assert.Equal(t, "a", result.Contributors[0].Name)
+		assert.Equal(t, "c", result.Contributors[1].Name)
+	})
+
+	t.Run("weighted mean", func(t *testing.T) {
+		options := newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "c", rate: 160},
+		)
+		options.ProviderWeights = map[string]float64{"a": 3, "c": 1}
+		client := newMockClient(options, nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationWeightedMean)
+		assert.NoError(t, err)
+		assert.Equal(t, 152.5, result.Rate)
+		assert.Equal(t, float64(3), result.Contributors[0].Weight)
+	})
+
+	t.Run("weighted mean - zero weights", func(t *testing.T) {
+		options := newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "c", rate: 160},
+		)
+		options.ProviderWeights = map[string]float64{"a": 0, "c": 0}
+		client := newMockClient(options, nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationWeightedMean)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(155), result.Rate)
+	})
+
+	t.Run("vwap", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockVolumeProvider{mockRateProvider: mockRateProvider{name: "a", rate: 150}, volume: 3000},
+			&mockVolumeProvider{mockRateProvider: mockRateProvider{name: "c", rate: 160}, volume: 1000},
+		), nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationVWAP)
+		assert.NoError(t, err)
+		assert.Equal(t, 152.5, result.Rate)
+		assert.Equal(t, float64(3000), result.Contributors[0].Volume24h)
+	})
+
+	t.Run("vwap - provider without volume uses equal weights", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockVolumeProvider{mockRateProvider: mockRateProvider{name: "a", rate: 150}, volume: 3000},
+			&mockVolumeProvider{mockRateProvider: mockRateProvider{name: "b", rate: 160}, volume: 1000},
+			&mockRateProvider{name: "c", rate: 170},
+		), nil, nil)
+
+		// Equal weights: (150 + 160 + 170) / 3 (not weighted by the 3000 and 1000 volumes)
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationVWAP)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(160), result.Rate)
+		assert.Equal(t, float64(0), result.Contributors[2].Volume24h)
+	})
+
+	t.Run("vwap - no volume data", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "c", rate: 160},
+		), nil, nil)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationVWAP)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(155), result.Rate)
+	})
+
+	t.Run("vwap - default providers", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationVWAP)
+		assert.NoError(t, err)
+		assert.Equal(t, 158.75207624, result.Rate)
+		assert.Equal(t, 719426754.25105, result.Contributors[0].Volume24h)
+		assert.Equal(t, float64(0), result.Contributors[1].Volume24h)
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, aggregationLast)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("non accepted currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+
+		result, err := client.GetAggregatedRate(context.Background(), 123, AggregationMedian)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("all providers failed", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+// TestClient_GetAggregatedConversion will test the method GetAggregatedConversion()
+func TestClient_GetAggregatedConversion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid conversion", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 100},
+			&mockRateProvider{name: "c", rate: 160},
+		), nil, nil)
+
+		result, err := client.GetAggregatedConversion(context.Background(), CurrencyDollars, 1.5, AggregationMedian)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(1000000), result.Satoshis)
+		assert.Equal(t, float64(150), result.Rate)
+		assert.Equal(t, 1.5, result.Amount)
+	})
+
+	t.Run("missing amount", func(t *testing.T) {
+		client := newMockClient(newMockOptions(&mockRateProvider{name: "a", rate: 150}), nil, nil)
+
+		result, err := client.GetAggregatedConversion(context.Background(), CurrencyDollars, 0, AggregationMedian)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("all providers failed", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
+
+		result, err := client.GetAggregatedConversion(context.Background(), CurrencyDollars, 1, AggregationMean)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+// TestClient_GetAggregatedRate_Consensus will test the consensus rules (quorum & outliers)
+func TestClient_GetAggregatedRate_Consensus(t *testing.T) {
+	t.Parallel()
+
+	// newConsensusClient returns a client with consensus rules and three custom providers
+	newConsensusClient := func(quorum int, maxDeviation float64, providers ...RateProvider) ClientInterface {
+		options := DefaultClientOptions()
+		options.CustomProviders = providers
+		options.MinimumQuorum = quorum
+		options.MaxDeviationPercent = maxDeviation
+		return NewClient(options, nil)
+	}
+
+	t.Run("outlier is rejected", func(t *testing.T) {
+		client := newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 15},
+			&mockRateProvider{name: "c", rate: 152},
+		)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMean)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, float64(151), result.Rate)
+		assert.Equal(t, 2, len(result.Contributors))
+		assert.Equal(t, 1, len(result.Rejected))
+		assert.Equal(t, "b", result.Rejected[0].Name)
+	})
+
+	t.Run("two providers - the first provider is the reference", func(t *testing.T) {
+		client := newConsensusClient(1, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 300},
+		)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), result.Rate)
+		if assert.Equal(t, 1, len(result.Rejected)) {
+			assert.Equal(t, "b", result.Rejected[0].Name)
+		}
+
+		// A quorum of two is not met when they disagree
+		client = newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 300},
+		)
+		_, err = client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		var quorumErr *QuorumError
+		if assert.ErrorAs(t, err, &quorumErr) {
+			assert.Equal(t, 1, quorumErr.Agreeing)
+			assert.Equal(t, 1, len(quorumErr.Rejected))
+		}
+
+		// And met when they agree
+		client = newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 154},
+		)
+		result, err = client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMean)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(152), result.Rate)
+	})
+
+	t.Run("quorum not met", func(t *testing.T) {
+		client := newConsensusClient(3, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b", rate: 15},
+			&mockRateProvider{name: "c", rate: 152},
+		)
+
+		result, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrQuorumNotMet)
+
+		var quorumErr *QuorumError
+		assert.ErrorAs(t, err, &quorumErr)
+		assert.Equal(t, 2, quorumErr.Agreeing)
+		assert.Equal(t, 3, quorumErr.Required)
+		assert.Equal(t, 1, len(quorumErr.Rejected))
+	})
+
+	t.Run("quorum not met - failed providers", func(t *testing.T) {
+		client := newConsensusClient(2, 0,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b"},
+		)
+
+		_, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.ErrorIs(t, err, ErrQuorumNotMet)
+		assert.Equal(t, "quorum not met: 1 of 2 required providers agreed (0 rejected, 1 failed)", err.Error())
+	})
+
+	t.Run("quorum not met - every provider failed", func(t *testing.T) {
+		client := newConsensusClient(2, 0,
+			&mockRateProvider{name: "a"},
+			&mockRateProvider{name: "b"},
+		)
+
+		_, err := client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+		assert.ErrorIs(t, err, ErrQuorumNotMet)
+		assert.Equal(t, "quorum not met: 0 of 2 required providers agreed (0 rejected, 2 failed)", err.Error())
+
+		var quorumErr *QuorumError
+		if assert.ErrorAs(t, err, &quorumErr) {
+			assert.Equal(t, 0, quorumErr.Agreeing)
+			assert.Equal(t, 2, quorumErr.Failed)
+		}
+		var errs ProviderErrors
+		if assert.ErrorAs(t, err, &errs) {
+			assert.Equal(t, 2, len(errs))
+			assert.Equal(t, "a", errs[0].Name)
+		}
+	})
+
+	t.Run("get rate uses the consensus", func(t *testing.T) {
+		client := newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 15},
+			&mockRateProvider{name: "b", rate: 150},
+			&mockRateProvider{name: "c", rate: 152},
+		)
+
+		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(151), rate)
+		assert.Equal(t, ProviderCustom, provider)
+	})
+
+	t.Run("get rate - quorum not met", func(t *testing.T) {
+		client := newConsensusClient(3, 5,
+			&mockRateProvider{name: "a", rate: 15},
+			&mockRateProvider{name: "b", rate: 150},
+			&mockRateProvider{name: "c", rate: 152},
+		)
+
+		rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.ErrorIs(t, err, ErrQuorumNotMet)
+		assert.Equal(t, float64(0), rate)
+	})
+
+	t.Run("get conversion uses the consensus", func(t *testing.T) {
+		client := newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 15},
+			&mockRateProvider{name: "b", rate: 150},
+			&mockRateProvider{name: "c", rate: 150},
+		)
+
+		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1.5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000), satoshis)
+		assert.Equal(t, ProviderCustom, provider)
+	})
+
+	t.Run("get conversion details keeps the provider errors", func(t *testing.T) {
+		client := newConsensusClient(2, 5,
+			&mockRateProvider{name: "a", rate: 150},
+			&mockRateProvider{name: "b"},
+			&mockRateProvider{name: "c", rate: 150},
+		)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1.5)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000), result.Satoshis)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, "b", result.Errors[0].Name)
+		}
+	})
+
+	t.Run("get conversion - quorum not met", func(t *testing.T) {
+		client := newConsensusClient(3, 5,
+			&mockRateProvider{name: "a", rate: 15},
+			&mockRateProvider{name: "b", rate: 150},
+		)
+
+		satoshis, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.ErrorIs(t, err, ErrQuorumNotMet)
+		assert.Equal(t, int64(0), satoshis)
+	})
+}
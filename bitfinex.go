@@ -0,0 +1,122 @@
+package bsvrates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// bitfinexBaseURL is the main url for the service (public endpoints)
+const bitfinexBaseURL = "https://api-pub.bitfinex.com/v2/"
+
+// Accepted currencies (BSV trading pairs) for Bitfinex
+var acceptedCurrenciesBitfinex = []string{
+	usd,
+}
+
+// BitfinexClient is the client for the Bitfinex exchange
+type BitfinexClient struct {
+	BaseURL    string        // url of the api (defaults to the public api, IE: a local stub server)
+	HTTPClient HTTPInterface // carries out the http operations (heimdall client)
+	Logger     Logger        // logs failed requests (optional)
+	UserAgent  string
+}
+
+// TickerBitfinex is the result returned from Bitfinex ticker request
+// (decoded from [bid, bid size, ask, ask size, daily change, daily change relative, last, volume, high, low])
+type TickerBitfinex struct {
+	Ask                 float64      `json:"ask"`                   // Lowest ask price
+	AskSize             float64      `json:"ask_size"`              // Size of the 25 lowest asks
+	Bid                 float64      `json:"bid"`                   // Highest bid price
+	BidSize             float64      `json:"bid_size"`              // Size of the 25 highest bids
+	DailyChange         float64      `json:"daily_change"`          // Change in the last price since yesterday
+	DailyChangeRelative float64      `json:"daily_change_relative"` // Relative change since yesterday (IE: 0.05 is 5%)
+	High                float64      `json:"high"`                  // Daily high
+	LastPrice           float64      `json:"last_price"`            // Price of the last trade
+	LastRequest         *lastRequest `json:"-"`                     // is the raw information from the last request
+	Low                 float64      `json:"low"`                   // Daily low
+	Symbol              string       `json:"symbol"`                // Trading pair (IE: tBSVUSD)
+	Volume              float64      `json:"volume"`                // Daily volume (in BSV)
+}
+
+// UnmarshalJSON will decode the ticker from the Bitfinex array
+func (t *TickerBitfinex) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	} else if len(values) != 10 {
+		return fmt.Errorf("expected 10 values in a ticker, got %d", len(values))
+	}
+	t.Bid, t.BidSize, t.Ask, t.AskSize = values[0], values[1], values[2], values[3]
+	t.DailyChange, t.DailyChangeRelative = values[4], values[5]
+	t.LastPrice, t.Volume, t.High, t.Low = values[6], values[7], values[8], values[9]
+	return nil
+}
+
+// BitfinexSymbol will return the BSV trading pair for the currency (IE: tBSVUSD)
+func BitfinexSymbol(currency string) string {
+	return "tBSV" + strings.ToUpper(currency)
+}
+
+// createBitfinexClient will make a new http client based on the options provided
+func createBitfinexClient(options *ClientOptions, customHTTPClient HTTPInterface) BitfinexInterface {
+
+	// Create a client
+	c := &BitfinexClient{BaseURL: bitfinexBaseURL}
+
+	// Set options (either default or user modified)
+	if options == nil {
+		options = DefaultClientOptions()
+	}
+
+	// Set the user agent and logger
+	c.UserAgent = options.UserAgent
+	c.Logger = options.Logger
+
+	// Is there a custom HTTP client to use?
+	if c.HTTPClient = customHTTPClient; c.HTTPClient == nil {
+		c.HTTPClient = createHTTPClient(options)
+	}
+	return c
+}
+
+// IsAcceptedCurrency will check if the currency is accepted (has a BSV trading pair)
+func (b *BitfinexClient) IsAcceptedCurrency(currency string) bool {
+	currency = strings.ToLower(currency)
+	for _, accepted := range acceptedCurrenciesBitfinex {
+		if accepted == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTicker returns a response of the ticker (bid, ask, last price and volume) for the trading pair
+//
+// See: https://docs.bitfinex.com/reference/rest-public-ticker
+func (b *BitfinexClient) GetTicker(ctx context.Context, symbol string) (response *TickerBitfinex, err error) {
+
+	// Start the response
+	response = &TickerBitfinex{LastRequest: new(lastRequest), Symbol: symbol}
+
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			b.logResponseError(ctx, response.LastRequest, err)
+		}
+	}()
+
+	// Set the api url
+	// ticker/tBSVUSD
+	baseURL := b.BaseURL
+	if len(baseURL) == 0 {
+		baseURL = bitfinexBaseURL
+	}
+	reqURL := strings.TrimSuffix(baseURL, "/") + "/ticker/" + url.PathEscape(symbol)
+
+	// Fire the request
+	err = requestJSON(ctx, b.HTTPClient, b.UserAgent, reqURL, response.LastRequest, response)
+	return
+}
@@ -0,0 +1,175 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBitfinexClient_IsAcceptedCurrency will test the method IsAcceptedCurrency()
+func TestBitfinexClient_IsAcceptedCurrency(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		currency         string
+		expectedAccepted bool
+	}{
+		{"usd", true},
+		{"USD", true},
+		{"eur", false},
+		{"bsv", false},
+		{"", false},
+	}
+	client := createBitfinexClient(nil, nil)
+	for _, test := range tests {
+		assert.Equal(t, test.expectedAccepted, client.IsAcceptedCurrency(test.currency), test.currency)
+	}
+}
+
+// TestBitfinexSymbol will test the method BitfinexSymbol()
+func TestBitfinexSymbol(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tBSVUSD", BitfinexSymbol("usd"))
+	assert.Equal(t, "tBSVUSD", BitfinexSymbol("USD"))
+}
+
+// TestBitfinexClient_GetTicker will test the method GetTicker()
+func TestBitfinexClient_GetTicker(t *testing.T) {
+	t.Parallel()
+
+	server := newMockBitfinexServer()
+	defer server.Close()
+	client := newMockBitfinexClient(server)
+
+	t.Run("valid ticker", func(t *testing.T) {
+		response, err := client.GetTicker(context.Background(), "tBSVUSD")
+		assert.NoError(t, err)
+		assert.Equal(t, "tBSVUSD", response.Symbol)
+		assert.Equal(t, 48.05, response.Bid)
+		assert.Equal(t, 48.11, response.Ask)
+		assert.Equal(t, 48.09, response.LastPrice)
+		assert.Equal(t, 35120.6, response.Volume)
+		assert.Equal(t, 49.2, response.High)
+		assert.Equal(t, 47.51, response.Low)
+		assert.Equal(t, http.StatusOK, response.LastRequest.StatusCode)
+		assert.Equal(t, server.URL+"/ticker/tBSVUSD", response.LastRequest.URL)
+	})
+
+	t.Run("unknown symbol", func(t *testing.T) {
+		response, err := client.GetTicker(context.Background(), "tBSVXYZ")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, response.LastRequest.StatusCode)
+	})
+
+	t.Run("malformed ticker", func(t *testing.T) {
+		_, err := client.GetTicker(context.Background(), "tBSVEUR")
+		assert.True(t, errors.Is(err, ErrDecode))
+	})
+
+	t.Run("request failed", func(t *testing.T) {
+		down := newMockBitfinexServer()
+		down.Close()
+		logger := &mockLogger{}
+		downClient := newMockBitfinexClient(down)
+		downClient.Logger = logger
+
+		_, err := downClient.GetTicker(context.Background(), "tBSVUSD")
+		assert.Error(t, err)
+		assert.NotNil(t, logger.find("warn", "bitfinex request failed"))
+	})
+}
+
+// TestTickerBitfinex_UnmarshalJSON will test the method UnmarshalJSON()
+func TestTickerBitfinex_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		data          string
+		expectedValid bool
+	}{
+		{`[48.05,1520.3,48.11,980.75,-0.42,-0.0087,48.09,35120.6,49.2,47.51]`, true},
+		{`[48.05,1520.3]`, false},
+		{`{"last_price":48.09}`, false},
+		{`["error",10020,"symbol: invalid"]`, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedValid, new(TickerBitfinex).UnmarshalJSON([]byte(test.data)) == nil, test.data)
+	}
+}
+
+// TestClient_Bitfinex will test the Bitfinex provider
+func TestClient_Bitfinex(t *testing.T) {
+	t.Parallel()
+
+	server := newMockBitfinexServer()
+	defer server.Close()
+
+	// newBitfinexClient will return a client using only Bitfinex (against the stub server)
+	newBitfinexClient := func() ClientInterface {
+		client := NewClient(nil, nil, ProviderBitfinex)
+		client.SetBitfinex(newMockBitfinexClient(server))
+		return client
+	}
+
+	t.Run("set and get the client", func(t *testing.T) {
+		client := NewClient(nil, nil)
+		assert.NotNil(t, client.Bitfinex())
+		client.SetBitfinex(nil)
+		assert.NotNil(t, client.Bitfinex())
+		assert.Equal(t, []Provider{ProviderCoinPaprika, ProviderWhatsOnChain}, client.Providers())
+	})
+
+	t.Run("rate with bid, ask and volume", func(t *testing.T) {
+		result, err := newBitfinexClient().GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderBitfinex, result.Provider)
+		assert.Equal(t, "Bitfinex", result.ProviderName)
+		assert.Equal(t, "48.09", result.Rate.String())
+		assert.Equal(t, "48.05", result.Bid.String())
+		assert.Equal(t, "48.11", result.Ask.String())
+		assert.Equal(t, "1688949.65", result.Volume24h.String())
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+		satoshis, providerUsed, err := newBitfinexClient().GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderBitfinex, providerUsed)
+		assert.Equal(t, int64(2079435), satoshis)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		provider := &bitfinexProvider{client: newBitfinexClient().(*Client)}
+		assert.True(t, provider.SupportsCurrency(CurrencyDollars))
+		assert.False(t, provider.SupportsCurrency(CurrencyEuro))
+
+		_, err := newBitfinexClient().GetRateResult(context.Background(), CurrencyEuro)
+		assert.Error(t, err)
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		report := newBitfinexClient().HealthCheck(context.Background())
+		assert.True(t, report.Healthy)
+		if assert.Equal(t, 1, len(report.Providers)) {
+			assert.Equal(t, ProviderBitfinex, report.Providers[0].Provider)
+			assert.Equal(t, HealthHealthy, report.Providers[0].Status)
+		}
+	})
+
+	t.Run("failed health check keeps the status code", func(t *testing.T) {
+		malformed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`[48.05,1520.3]`))
+		}))
+		defer malformed.Close()
+		client := NewClient(nil, nil, ProviderBitfinex)
+		client.SetBitfinex(newMockBitfinexClient(malformed))
+
+		err := (&bitfinexProvider{client: client.(*Client)}).HealthCheck(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusOK, errorStatusCode(err))
+	})
+}
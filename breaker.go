@@ -0,0 +1,172 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is how long an open circuit skips the provider (if not set)
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// CircuitState is the state of a provider circuit breaker
+type CircuitState uint8
+
+// CircuitState constants for the different breaker states.
+// Leave the start and last constants in place
+const (
+	CircuitClosed CircuitState = iota // 0 (requests are sent to the provider)
+
+	CircuitOpen     // 1 (the provider is skipped until the cooldown passes)
+	CircuitHalfOpen // 2 (one probe request is sent to check if the provider recovered)
+	circuitLast     // 3
+)
+
+// Name will return the display name for the given circuit state
+func (s CircuitState) Name() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	case circuitLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// circuitBreaker tracks the consecutive failures of a single provider
+type circuitBreaker struct {
+	failures int          // Consecutive failures
+	mu       sync.Mutex   // Guards the breaker
+	openedAt time.Time    // When the circuit was opened
+	probing  bool         // A probe request is in-flight (half-open)
+	state    CircuitState // Current state
+}
+
+// circuitBreakers is the circuit breaker for each provider (keyed by provider name)
+type circuitBreakers struct {
+	breakers  map[string]*circuitBreaker // Breakers by provider name
+	cooldown  time.Duration              // How long an open circuit skips the provider
+	mu        sync.Mutex                 // Guards the breakers map
+	threshold int                        // Consecutive failures before the circuit opens
+}
+
+// newCircuitBreakers will return the circuit breakers (nil if the threshold is not set)
+func newCircuitBreakers(threshold int, cooldown time.Duration) *circuitBreakers {
+	if threshold <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &circuitBreakers{
+		breakers:  make(map[string]*circuitBreaker),
+		cooldown:  cooldown,
+		threshold: threshold,
+	}
+}
+
+// get will return the breaker for the provider (created if not found)
+func (b *circuitBreakers) get(name string) *circuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker, ok := b.breakers[name]
+	if !ok {
+		breaker = new(circuitBreaker)
+		b.breakers[name] = breaker
+	}
+	return breaker
+}
+
+// allow will return ErrCircuitOpen if the provider should be skipped
+// (once the cooldown passes, one probe request is allowed through)
+func (b *circuitBreakers) allow(name string) error {
+	if b == nil {
+		return nil
+	}
+	breaker := b.get(name)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	// Open (half-open once the cooldown passes)
+	if breaker.state == CircuitOpen {
+		if time.Since(breaker.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		breaker.state = CircuitHalfOpen
+	}
+
+	// Half-open (only one probe at a time)
+	if breaker.state == CircuitHalfOpen {
+		if breaker.probing {
+			return ErrCircuitOpen
+		}
+		breaker.probing = true
+	}
+	return nil
+}
+
+// record will record the result of a request to the provider
+// (requests cancelled by the caller are not counted)
+func (b *circuitBreakers) record(name string, err error) {
+	if b == nil {
+		return
+	}
+	breaker := b.get(name)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	breaker.probing = false
+
+	// Cancelled (not a provider failure)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	// Success (close the circuit)
+	if err == nil {
+		breaker.failures = 0
+		breaker.state = CircuitClosed
+		return
+	}
+
+	// Failure (open the circuit if the probe failed or the threshold is reached)
+	breaker.failures++
+	if breaker.state == CircuitHalfOpen || breaker.failures >= b.threshold {
+		breaker.state = CircuitOpen
+		breaker.openedAt = time.Now()
+	}
+}
+
+// state will return the state of the provider circuit
+func (b *circuitBreakers) state(name string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	breaker := b.get(name)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if breaker.state == CircuitOpen && time.Since(breaker.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return breaker.state
+}
+
+// CircuitState will return the state of the circuit breaker for the provider (by name).
+// The state is always CircuitClosed if ClientOptions.CircuitBreakerThreshold is not set
+func (c *Client) CircuitState(providerName string) CircuitState {
+	return c.breakers.state(providerName)
+}
+
+// CircuitStates will return the state of the circuit breaker for every provider (by name)
+func (c *Client) CircuitStates() map[string]CircuitState {
+	states := make(map[string]CircuitState)
+	for _, provider := range c.RateProviders() {
+		states[provider.Name()] = c.CircuitState(provider.Name())
+	}
+	return states
+}
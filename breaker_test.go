@@ -0,0 +1,161 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCircuitState_Name will test the method Name()
+func TestCircuitState_Name(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		state        CircuitState
+		expectedName string
+	}{
+		{CircuitClosed, "closed"},
+		{CircuitOpen, "open"},
+		{CircuitHalfOpen, "half-open"},
+		{circuitLast, ""},
+		{123, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedName, test.state.Name())
+	}
+}
+
+// TestCircuitBreakers will test the circuit breakers
+func TestCircuitBreakers(t *testing.T) {
+	t.Parallel()
+
+	failed := errors.New("provider failed")
+
+	t.Run("disabled", func(t *testing.T) {
+		breakers := newCircuitBreakers(0, time.Minute)
+		assert.Nil(t, breakers)
+		breakers.record("test", failed)
+		assert.NoError(t, breakers.allow("test"))
+		assert.Equal(t, CircuitClosed, breakers.state("test"))
+	})
+
+	t.Run("default cooldown", func(t *testing.T) {
+		breakers := newCircuitBreakers(1, 0)
+		assert.Equal(t, defaultCircuitBreakerCooldown, breakers.cooldown)
+	})
+
+	t.Run("opens after the threshold", func(t *testing.T) {
+		breakers := newCircuitBreakers(2, time.Minute)
+		assert.NoError(t, breakers.allow("test"))
+		breakers.record("test", failed)
+		assert.Equal(t, CircuitClosed, breakers.state("test"))
+
+		assert.NoError(t, breakers.allow("test"))
+		breakers.record("test", failed)
+		assert.Equal(t, CircuitOpen, breakers.state("test"))
+		assert.True(t, errors.Is(breakers.allow("test"), ErrCircuitOpen))
+
+		// Other providers are not affected
+		assert.NoError(t, breakers.allow("other"))
+	})
+
+	t.Run("success resets the failures", func(t *testing.T) {
+		breakers := newCircuitBreakers(2, time.Minute)
+		breakers.record("test", failed)
+		breakers.record("test", nil)
+		breakers.record("test", failed)
+		assert.Equal(t, CircuitClosed, breakers.state("test"))
+	})
+
+	t.Run("cancelled requests are not counted", func(t *testing.T) {
+		breakers := newCircuitBreakers(1, time.Minute)
+		breakers.record("test", context.Canceled)
+		assert.Equal(t, CircuitClosed, breakers.state("test"))
+	})
+
+	t.Run("half-open - one probe, success closes", func(t *testing.T) {
+		breakers := newCircuitBreakers(1, 10*time.Millisecond)
+		breakers.record("test", failed)
+		assert.Equal(t, CircuitOpen, breakers.state("test"))
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, CircuitHalfOpen, breakers.state("test"))
+		assert.NoError(t, breakers.allow("test"))
+		assert.True(t, errors.Is(breakers.allow("test"), ErrCircuitOpen))
+
+		breakers.record("test", nil)
+		assert.Equal(t, CircuitClosed, breakers.state("test"))
+		assert.NoError(t, breakers.allow("test"))
+	})
+
+	t.Run("half-open - failed probe opens again", func(t *testing.T) {
+		breakers := newCircuitBreakers(3, 10*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			breakers.record("test", failed)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, breakers.allow("test"))
+		breakers.record("test", failed)
+		assert.Equal(t, CircuitOpen, breakers.state("test"))
+		assert.True(t, errors.Is(breakers.allow("test"), ErrCircuitOpen))
+	})
+}
+
+// TestClient_CircuitBreaker will test skipping a provider with an open circuit
+func TestClient_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("open circuit skips the provider", func(t *testing.T) {
+		down := &mockRateProvider{name: "down"}
+		options := DefaultClientOptions()
+		options.CircuitBreakerThreshold = 2
+		options.CircuitBreakerCooldown = time.Minute
+		options.CustomProviders = []RateProvider{down, &mockRateProvider{name: "in-house", rate: 150}}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 3; i++ {
+			rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+			assert.Equal(t, float64(150), rate)
+		}
+		assert.Equal(t, int64(2), down.rateCalls())
+		assert.Equal(t, CircuitOpen, client.CircuitState("down"))
+		assert.Equal(t, map[string]CircuitState{
+			"down":     CircuitOpen,
+			"in-house": CircuitClosed,
+		}, client.CircuitStates())
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.True(t, errors.Is(result.Errors[0], ErrCircuitOpen))
+		}
+	})
+
+	t.Run("open circuit - conversion", func(t *testing.T) {
+		down := &mockRateProvider{name: "down"}
+		options := DefaultClientOptions()
+		options.CircuitBreakerThreshold = 1
+		options.CustomProviders = []RateProvider{down}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 2; i++ {
+			_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+			assert.Error(t, err)
+		}
+		assert.True(t, errors.Is(func() error {
+			_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+			return err
+		}(), ErrCircuitOpen))
+		assert.Equal(t, CircuitOpen, client.CircuitState("down"))
+	})
+
+	t.Run("disabled - always closed", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
+		assert.Equal(t, CircuitClosed, client.CircuitState("CoinPaprika"))
+	})
+}
@@ -0,0 +1,213 @@
+package bsvrates
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// CacheStatus is the status of the cache for a rate lookup
+type CacheStatus uint8
+
+// CacheStatus constants for the different cache results.
+// Leave the start and last constants in place
+const (
+	CacheDisabled CacheStatus = iota // 0 (no cache configured)
+
+	CacheMiss  // 1 (fetched from the provider)
+	CacheHit   // 2 (served from the cache)
+	CacheStale // 3 (served from the cache while refreshing in the background)
+	cacheLast  // 4
+)
+
+// Name will return the display name for the given cache status
+func (s CacheStatus) Name() string {
+	switch s {
+	case CacheDisabled:
+		return "disabled"
+	case CacheMiss:
+		return "miss"
+	case CacheHit:
+		return "hit"
+	case CacheStale:
+		return "stale"
+	case cacheLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// combineCacheStatus will return the "worst" status (miss > stale > hit) of the given statuses
+func combineCacheStatus(statuses ...CacheStatus) (combined CacheStatus) {
+	for _, status := range statuses {
+		switch {
+		case status == CacheMiss:
+			return CacheMiss
+		case status == CacheStale:
+			combined = CacheStale
+		case status == CacheHit && combined != CacheStale:
+			combined = CacheHit
+		}
+	}
+	return
+}
+
+// cachePrefix is the prefix for all cache keys
+const cachePrefix = "bsvrates:"
+
+// rateCache is a cache of rates (keyed by provider and currency) stored in a CacheBackend
+type rateCache struct {
+	backend              CacheBackend    // Where the rates are stored
+	mu                   sync.Mutex      // Guards the refreshing map
+	refreshing           map[string]bool // Keys that are being refreshed in the background
+	staleWhileRevalidate time.Duration   // How long an expired rate can be served while refreshing
+	ttl                  time.Duration   // How long a rate is fresh
+}
+
+// newRateCache will return a new cache (nil if the ttl is not set)
+func newRateCache(backend CacheBackend, ttl, staleWhileRevalidate time.Duration) *rateCache {
+	if ttl <= 0 {
+		return nil
+	}
+	if backend == nil {
+		backend = NewMemoryCache()
+	}
+	return &rateCache{
+		backend:              backend,
+		refreshing:           make(map[string]bool),
+		staleWhileRevalidate: staleWhileRevalidate,
+		ttl:                  ttl,
+	}
+}
+
+// cacheKey will return the cache key for the provider and currency
+func cacheKey(provider RateProvider, currency Currency) string {
+	return provider.Name() + ":" + currency.Name()
+}
+
+// get will return the cached quote and the status (CacheMiss if not found, expired or the backend fails)
+func (r *rateCache) get(ctx context.Context, key string) (quote *Quote, status CacheStatus) {
+
+	// Get the quote from the backend
+	value, found, err := r.backend.Get(ctx, cachePrefix+"rate:"+key)
+	if err != nil || !found {
+		return nil, CacheMiss
+	}
+	quote = new(Quote)
+	if err = json.Unmarshal(value, quote); err != nil || quote.Rate <= 0 {
+		return nil, CacheMiss
+	}
+
+	// Check the age
+	age := time.Since(quote.FetchedAt)
+	switch {
+	case age < r.ttl:
+		return quote, CacheHit
+	case age < r.ttl+r.staleWhileRevalidate:
+		return quote, CacheStale
+	default:
+		return nil, CacheMiss
+	}
+}
+
+// set will store the quote in the backend (kept for the ttl and stale window)
+func (r *rateCache) set(ctx context.Context, key string, quote *Quote) {
+	if value, err := json.Marshal(quote); err == nil {
+		_ = r.backend.Set(ctx, cachePrefix+"rate:"+key, value, r.ttl+r.staleWhileRevalidate)
+	}
+}
+
+// startRefresh will mark the key as refreshing (false if it is already refreshing)
+func (r *rateCache) startRefresh(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.refreshing[key] {
+		return false
+	}
+	r.refreshing[key] = true
+	return true
+}
+
+// endRefresh will mark the key as no longer refreshing
+func (r *rateCache) endRefresh(key string) {
+	r.mu.Lock()
+	delete(r.refreshing, key)
+	r.mu.Unlock()
+}
+
+// getProviderRate will get the rate from the provider (using the cache if enabled)
+func (c *Client) getProviderRate(ctx context.Context, provider RateProvider,
+	currency Currency) (rate float64, status CacheStatus, err error) {
+	var quote *Quote
+	if quote, status, err = c.getProviderQuote(ctx, provider, currency); err == nil {
+		rate = quote.Rate
+	}
+	return
+}
+
+// getProviderQuote will get the quote from the provider (using the cache if enabled)
+// and reject it if it is older than ClientOptions.MaxRateAge
+func (c *Client) getProviderQuote(ctx context.Context, provider RateProvider,
+	currency Currency) (quote *Quote, status CacheStatus, err error) {
+	if quote, status, err = c.lookupProviderQuote(ctx, provider, currency); err == nil {
+		if err = quote.checkAge(c.options.MaxRateAge); err != nil {
+			quote = nil
+		}
+	}
+	return
+}
+
+// lookupProviderQuote will get the quote from the cache or the provider (any age)
+func (c *Client) lookupProviderQuote(ctx context.Context, provider RateProvider,
+	currency Currency) (quote *Quote, status CacheStatus, err error) {
+
+	// No cache
+	if c.cache == nil {
+		quote, err = c.fetchProviderQuote(ctx, provider, currency)
+		return
+	}
+
+	// Check the cache (a cached quote older than MaxRateAge is a miss, so a fresh quote is fetched)
+	key := cacheKey(provider, currency)
+	if quote, status = c.cache.get(ctx, key); quote != nil && quote.checkAge(c.options.MaxRateAge) != nil {
+		quote, status = nil, CacheMiss
+	}
+	c.metrics.ObserveCache(provider.Name(), currency, status)
+	if status == CacheHit {
+		c.logCache(ctx, provider, currency, status)
+		return
+	} else if status == CacheStale {
+		c.logCache(ctx, provider, currency, status)
+		c.refreshProviderQuote(provider, currency, key)
+		return
+	}
+
+	// Fetch from the provider and store
+	if quote, err = c.fetchProviderQuote(ctx, provider, currency); err == nil {
+		c.cache.set(ctx, key, quote)
+	}
+	return
+}
+
+// refreshProviderQuote will refresh the cached quote in the background (once per key)
+func (c *Client) refreshProviderQuote(provider RateProvider, currency Currency, key string) {
+	if !c.cache.startRefresh(key) {
+		return
+	}
+	go func() {
+		defer c.cache.endRefresh(key)
+		ctx, cancel := c.backgroundContext()
+		defer cancel()
+		if quote, err := c.fetchProviderQuote(ctx, provider, currency); err == nil {
+			c.cache.set(ctx, key, quote)
+		} else {
+			c.logger.WarnContext(ctx, "background rate refresh failed",
+				"provider", provider.Name(),
+				"currency", currency.Name(),
+				"error", err.Error(),
+			)
+		}
+	}()
+}
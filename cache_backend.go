@@ -0,0 +1,91 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheBackend is the interface for a cache store used for rates and historical tickers.
+// Implement this interface to share one fetched rate across a fleet (IE: Redis or memcached)
+type CacheBackend interface {
+	Delete(ctx context.Context, key string) error
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// memorySweepInterval is how often MemoryCache.Set removes every expired value
+const memorySweepInterval = time.Minute
+
+// memoryItem is a value stored in the MemoryCache
+type memoryItem struct {
+	expiresAt time.Time // When the value expires (zero never expires)
+	value     []byte    // Stored value
+}
+
+// MemoryCache is an in-memory CacheBackend (the default backend)
+type MemoryCache struct {
+	items   map[string]*memoryItem // Stored values
+	mu      sync.RWMutex           // Guards the items map
+	sweptAt time.Time              // When the expired values were last removed
+}
+
+// NewMemoryCache will return a new in-memory cache backend
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: make(map[string]*memoryItem)}
+}
+
+// Delete will remove the value for the key
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.items, key)
+	m.mu.Unlock()
+	return nil
+}
+
+// Get will return the value for the key (found is false if missing or expired, expired values are removed)
+func (m *MemoryCache) Get(_ context.Context, key string) (value []byte, found bool, err error) {
+	m.mu.RLock()
+	item, ok := m.items[key]
+	m.mu.RUnlock()
+	if !ok {
+		return
+	} else if item.isExpired() {
+		m.mu.Lock()
+		if current, exists := m.items[key]; exists && current.isExpired() {
+			delete(m.items, key)
+		}
+		m.mu.Unlock()
+		return
+	}
+	return item.value, true, nil
+}
+
+// Set will store the value for the key (a ttl of zero never expires).
+// Every expired value is also removed (at most once per sweep interval)
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	item := &memoryItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = now.Add(ttl)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = item
+
+	// Remove the expired values
+	if now.Sub(m.sweptAt) >= memorySweepInterval {
+		for storedKey, stored := range m.items {
+			if stored.isExpired() {
+				delete(m.items, storedKey)
+			}
+		}
+		m.sweptAt = now
+	}
+	return nil
+}
+
+// isExpired returns true if the item has expired
+func (i *memoryItem) isExpired() bool {
+	return !i.expiresAt.IsZero() && time.Now().After(i.expiresAt)
+}
@@ -0,0 +1,117 @@
+package bsvrates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryCache will test the MemoryCache backend
+func TestMemoryCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		cache := NewMemoryCache()
+		ctx := context.Background()
+
+		value, found, err := cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, value)
+
+		assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Minute))
+		value, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("value"), value)
+
+		assert.NoError(t, cache.Delete(ctx, "key"))
+		_, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("expired value", func(t *testing.T) {
+		cache := NewMemoryCache()
+		ctx := context.Background()
+
+		assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+		_, found, err := cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, 0, len(cache.items))
+	})
+
+	t.Run("expired values are swept on set", func(t *testing.T) {
+		cache := NewMemoryCache()
+		ctx := context.Background()
+
+		assert.NoError(t, cache.Set(ctx, "old", []byte("value"), time.Millisecond))
+		assert.NoError(t, cache.Set(ctx, "kept", []byte("value"), time.Minute))
+		time.Sleep(5 * time.Millisecond)
+		cache.sweptAt = time.Time{}
+
+		assert.NoError(t, cache.Set(ctx, "new", []byte("value"), time.Minute))
+		assert.Equal(t, 2, len(cache.items))
+		_, found, err := cache.Get(ctx, "kept")
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("no expiration", func(t *testing.T) {
+		cache := NewMemoryCache()
+		ctx := context.Background()
+
+		assert.NoError(t, cache.Set(ctx, "key", []byte("value"), 0))
+		_, found, err := cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+}
+
+// TestClient_SharedCacheBackend will test sharing one cache backend across clients (a fleet)
+func TestClient_SharedCacheBackend(t *testing.T) {
+	t.Parallel()
+
+	backend := NewMemoryCache()
+
+	// newSharedClient returns a client using the shared backend
+	newSharedClient := func(provider RateProvider) ClientInterface {
+		options := DefaultClientOptions()
+		options.CacheBackend = backend
+		options.CacheTTL = time.Minute
+		options.CustomProviders = []RateProvider{provider}
+		return NewClient(options, nil)
+	}
+
+	first := &mockRateProvider{name: "in-house", rate: 150}
+	second := &mockRateProvider{name: "in-house", rate: 150}
+
+	_, _, status, err := newSharedClient(first).GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, status)
+
+	var rate float64
+	rate, _, status, err = newSharedClient(second).GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, status)
+	assert.Equal(t, float64(150), rate)
+	assert.Equal(t, int64(1), first.rateCalls())
+	assert.Equal(t, int64(0), second.rateCalls())
+}
+
+// TestRateCache_InvalidEntry will test a corrupt entry in the backend
+func TestRateCache_InvalidEntry(t *testing.T) {
+	t.Parallel()
+
+	backend := NewMemoryCache()
+	cache := newRateCache(backend, time.Minute, 0)
+	assert.NoError(t, backend.Set(context.Background(), cachePrefix+"rate:key", []byte("{bad json"), 0))
+
+	quote, status := cache.get(context.Background(), "key")
+	assert.Nil(t, quote)
+	assert.Equal(t, CacheMiss, status)
+}
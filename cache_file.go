@@ -0,0 +1,106 @@
+package bsvrates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileItem is a value stored in the FileCache
+type fileItem struct {
+	ExpiresAt time.Time `json:"expires_at"` // When the value expires (zero never expires)
+	Value     []byte    `json:"value"`      // Stored value
+}
+
+// FileCache is a file-backed CacheBackend (one file per key in the directory)
+type FileCache struct {
+	directory string // Directory for the cache files
+}
+
+// NewFileCache will return a new file-backed cache backend (the directory is created if missing)
+func NewFileCache(directory string) (*FileCache, error) {
+	if len(directory) == 0 {
+		return nil, fmt.Errorf("missing cache directory")
+	}
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileCache{directory: directory}, nil
+}
+
+// Delete will remove the value for the key
+func (f *FileCache) Delete(_ context.Context, key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Get will return the value for the key (found is false if missing or expired)
+func (f *FileCache) Get(ctx context.Context, key string) (value []byte, found bool, err error) {
+
+	// Read the file
+	var contents []byte
+	if contents, err = ioutil.ReadFile(f.path(key)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+
+	// Decode the item
+	item := new(fileItem)
+	if err = json.Unmarshal(contents, item); err != nil {
+		return
+	}
+
+	// Remove expired items
+	if !item.ExpiresAt.IsZero() && time.Now().After(item.ExpiresAt) {
+		err = f.Delete(ctx, key)
+		return
+	}
+	return item.Value, true, nil
+}
+
+// Set will store the value for the key (a ttl of zero never expires)
+func (f *FileCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+
+	// Encode the item
+	item := &fileItem{Value: value}
+	if ttl > 0 {
+		item.ExpiresAt = time.Now().Add(ttl)
+	}
+	contents, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename (readers never see a partial file)
+	var tempFile *os.File
+	if tempFile, err = ioutil.TempFile(f.directory, ".tmp-*"); err != nil {
+		return err
+	}
+	if _, err = tempFile.Write(contents); err != nil {
+		_ = tempFile.Close()
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+	return os.Rename(tempFile.Name(), f.path(key))
+}
+
+// path will return the file path for the key
+func (f *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.directory, hex.EncodeToString(hash[:])+".json")
+}
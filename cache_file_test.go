@@ -0,0 +1,104 @@
+package bsvrates
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewFileCache will test the method NewFileCache()
+func TestNewFileCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid directory", func(t *testing.T) {
+		cache, err := NewFileCache(filepath.Join(t.TempDir(), "nested", "cache"))
+		assert.NoError(t, err)
+		assert.NotNil(t, cache)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		cache, err := NewFileCache("")
+		assert.Error(t, err)
+		assert.Nil(t, cache)
+	})
+}
+
+// TestFileCache will test the FileCache backend
+func TestFileCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		assert.NoError(t, err)
+		ctx := context.Background()
+
+		var value []byte
+		var found bool
+		value, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, value)
+
+		assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Minute))
+		value, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("value"), value)
+
+		assert.NoError(t, cache.Delete(ctx, "key"))
+		assert.NoError(t, cache.Delete(ctx, "key"))
+		_, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("expired value", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		assert.NoError(t, err)
+		ctx := context.Background()
+
+		assert.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		var found bool
+		_, found, err = cache.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(cache.path("key"), []byte("{bad json"), 0o600))
+
+		var found bool
+		_, found, err = cache.Get(context.Background(), "key")
+		assert.Error(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("shared by clients", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		assert.NoError(t, err)
+
+		options := DefaultClientOptions()
+		options.CacheBackend = cache
+		options.CacheTTL = time.Minute
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house", rate: 150}}
+		_, _, _, err = NewClient(options, nil).GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		options.CustomProviders = []RateProvider{provider}
+
+		var status CacheStatus
+		_, _, status, err = NewClient(options, nil).GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, CacheHit, status)
+		assert.Equal(t, int64(0), provider.rateCalls())
+	})
+}
@@ -0,0 +1,220 @@
+package bsvrates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCacheStatus_Name will test the method Name()
+func TestCacheStatus_Name(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		testCase     string
+		status       CacheStatus
+		expectedName string
+	}{
+		{"CacheDisabled", CacheDisabled, "disabled"},
+		{"CacheMiss", CacheMiss, "miss"},
+		{"CacheHit", CacheHit, "hit"},
+		{"CacheStale", CacheStale, "stale"},
+		{"cacheLast", cacheLast, ""},
+		{"status 10", 10, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.testCase, func(t *testing.T) {
+			assert.Equal(t, test.expectedName, test.status.Name())
+		})
+	}
+}
+
+// TestCombineCacheStatus will test the method combineCacheStatus()
+func TestCombineCacheStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, CacheDisabled, combineCacheStatus())
+	assert.Equal(t, CacheDisabled, combineCacheStatus(CacheDisabled, CacheDisabled))
+	assert.Equal(t, CacheHit, combineCacheStatus(CacheHit, CacheHit))
+	assert.Equal(t, CacheStale, combineCacheStatus(CacheHit, CacheStale, CacheHit))
+	assert.Equal(t, CacheMiss, combineCacheStatus(CacheStale, CacheMiss, CacheHit))
+}
+
+// TestClient_GetCachedRate will test the method GetCachedRate()
+func TestClient_GetCachedRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cache disabled", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		client := newMockClient(newMockOptions(provider), nil, nil)
+
+		for i := 0; i < 2; i++ {
+			rate, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+			assert.Equal(t, float64(150), rate)
+			assert.Equal(t, CacheDisabled, status)
+		}
+		assert.Equal(t, int64(2), provider.rateCalls())
+	})
+
+	t.Run("cache miss then hit", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		options := newMockOptions(provider)
+		options.CacheTTL = time.Minute
+		client := newMockClient(options, nil, nil)
+
+		rate, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, CacheMiss, status)
+
+		rate, _, status, err = client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, CacheHit, status)
+		assert.Equal(t, int64(1), provider.rateCalls())
+
+		// Different currency is a different key
+		_, _, status, err = client.GetCachedRate(context.Background(), CurrencyEuro)
+		assert.NoError(t, err)
+		assert.Equal(t, CacheMiss, status)
+		assert.Equal(t, int64(2), provider.rateCalls())
+	})
+
+	t.Run("stale while revalidate", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		options := newMockOptions(provider)
+		options.CacheTTL = 10 * time.Millisecond
+		options.CacheStaleWhileRevalidate = time.Minute
+		client := newMockClient(options, nil, nil)
+
+		_, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, CacheMiss, status)
+
+		time.Sleep(20 * time.Millisecond)
+
+		var rate float64
+		rate, _, status, err = client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, CacheStale, status)
+
+		// Refreshed in the background
+		assert.Eventually(t, func() bool {
+			_, _, status, _ = client.GetCachedRate(context.Background(), CurrencyDollars)
+			return status == CacheHit
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, int64(2), provider.rateCalls())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		options := newMockOptions(provider)
+		options.CacheTTL = 5 * time.Millisecond
+		client := newMockClient(options, nil, nil)
+
+		_, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, CacheMiss, status)
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, _, status, err = client.GetCachedRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, CacheMiss, status)
+		assert.Equal(t, int64(2), provider.rateCalls())
+	})
+
+	t.Run("failed rates are not cached", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house"}
+		options := newMockOptions(provider)
+		options.CacheTTL = time.Minute
+		client := newMockClient(options, nil, nil)
+
+		for i := 0; i < 2; i++ {
+			_, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+			assert.Error(t, err)
+			assert.Equal(t, CacheMiss, status)
+		}
+		assert.Equal(t, int64(2), provider.rateCalls())
+	})
+}
+
+// TestClient_GetCachedRate_MaxRateAge will test the cache with a maximum rate age (shorter than the ttl)
+func TestClient_GetCachedRate_MaxRateAge(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockRateProvider{name: "in-house", rate: 150}
+	options := newMockOptions(provider)
+	options.CacheTTL = time.Minute
+	options.MaxRateAge = 50 * time.Millisecond
+	client := newMockClient(options, nil, nil)
+
+	// Fresh enough to use the cache
+	_, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, status)
+	_, _, status, err = client.GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, status)
+	assert.Equal(t, int64(1), provider.rateCalls())
+
+	// Older than the maximum age (a fresh quote is fetched)
+	time.Sleep(100 * time.Millisecond)
+	rate, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(150), rate)
+	assert.Equal(t, CacheMiss, status)
+	assert.Equal(t, int64(2), provider.rateCalls())
+}
+
+// TestClient_GetConversionDetails_Cache will test conversions using the cache
+func TestClient_GetConversionDetails_Cache(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockRateProvider{name: "in-house", rate: 150}
+	options := newMockOptions(provider)
+	options.CacheTTL = time.Minute
+	client := newMockClient(options, nil, nil)
+
+	result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1.5)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(1000000), result.Satoshis)
+	assert.Equal(t, CacheMiss, result.Cache)
+
+	result, err = client.GetConversionDetails(context.Background(), CurrencyDollars, 3)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(2000000), result.Satoshis)
+	assert.Equal(t, CacheHit, result.Cache)
+	assert.Equal(t, int64(1), provider.rateCalls())
+}
+
+// TestClient_GetAggregatedRate_Cache will test aggregated rates using the cache
+func TestClient_GetAggregatedRate_Cache(t *testing.T) {
+	t.Parallel()
+
+	options := DefaultClientOptions()
+	options.CacheTTL = time.Minute
+	options.MinimumQuorum = 2
+	options.CustomProviders = []RateProvider{
+		&mockRateProvider{name: "a", rate: 150},
+		&mockRateProvider{name: "b", rate: 152},
+	}
+	client := NewClient(options, nil)
+
+	_, _, status, err := client.GetCachedRate(context.Background(), CurrencyDollars)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, status)
+
+	var result *AggregatedRate
+	result, err = client.GetAggregatedRate(context.Background(), CurrencyDollars, AggregationMedian)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, CacheHit, result.Contributors[0].Cache)
+	assert.Equal(t, CacheHit, result.Contributors[1].Cache)
+}
@@ -3,6 +3,7 @@ package bsvrates
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/mrz1836/go-whatsonchain"
@@ -16,34 +17,81 @@ type HTTPInterface interface {
 // CoinPaprikaInterface is an interface for the Coin Paprika Client
 type CoinPaprikaInterface interface {
 	GetBaseAmountAndCurrencyID(currency string, amount float64) (string, float64)
+	GetGlobal(ctx context.Context) (response *GlobalResponse, err error)
 	GetHistoricalTickers(ctx context.Context, coinID string, start, end time.Time, limit int, quote tickerQuote, interval tickerInterval) (response *HistoricalResponse, err error)
 	GetMarketPrice(ctx context.Context, coinID string) (response *TickerResponse, err error)
 	GetPriceConversion(ctx context.Context, baseCurrencyID, quoteCurrencyID string, amount float64) (response *PriceConversionResponse, err error)
 	IsAcceptedCurrency(currency string) bool
 }
 
+// BitfinexInterface is an interface for the Bitfinex Client
+type BitfinexInterface interface {
+	GetTicker(ctx context.Context, symbol string) (response *TickerBitfinex, err error)
+	IsAcceptedCurrency(currency string) bool
+}
+
+// CoinGeckoInterface is an interface for the CoinGecko Client
+type CoinGeckoInterface interface {
+	GetMarketChartRange(ctx context.Context, coinID, currency string, from, to time.Time) (response *MarketChartResponse, err error)
+	GetOHLC(ctx context.Context, coinID, currency string, days int) (response *OHLCResponse, err error)
+	GetSimplePrice(ctx context.Context, coinID string, currencies ...string) (response *SimplePriceResponse, err error)
+	IsAcceptedCurrency(currency string) bool
+	Ping(ctx context.Context) (response *PingResponse, err error)
+}
+
 // Client is the parent struct that contains the provider clients and list of providers to use
 type Client struct {
-	coinPaprika  CoinPaprikaInterface      // Coin Paprika client
-	providers    []Provider                // List of providers to use (in order for fail-over)
-	whatsOnChain whatsonchain.ChainService // WhatsOnChain (chain services)
+	bitfinex      BitfinexInterface         // Bitfinex client
+	breakers      *circuitBreakers          // Circuit breaker per provider (nil if disabled)
+	cache         *rateCache                // Rate cache (nil if disabled)
+	coinGecko     CoinGeckoInterface        // CoinGecko client
+	coinPaprika   CoinPaprikaInterface      // Coin Paprika client
+	fiatRates     FiatRateSource            // Fiat rates (cross-conversion for USD only providers)
+	flights       *flightGroup              // In-flight rate requests (nil if coalescing is disabled)
+	health        *healthStats              // Recent request results per provider (rolling success rate)
+	limiters      *rateLimiters             // Rate limiter per provider (limits and Retry-After)
+	logger        Logger                    // Structured logger (discards everything by default)
+	metrics       Metrics                   // Metrics hook (discards everything by default)
+	options       *ClientOptions            // Client options (set in NewClient)
+	providersMu   sync.RWMutex              // Guards the provider registry
+	rateProviders []RateProvider            // Registry of providers to use (in order for fail-over)
+	tracer        Tracer                    // Tracer for rate lookups (no spans by default)
+	whatsOnChain  whatsonchain.ChainService // WhatsOnChain (chain services)
 }
 
 // ClientOptions holds all the configuration for connection, dialer and transport
 type ClientOptions struct {
-	BackOffExponentFactor          float64       `json:"back_off_exponent_factor"`
-	BackOffInitialTimeout          time.Duration `json:"back_off_initial_timeout"`
-	BackOffMaximumJitterInterval   time.Duration `json:"back_off_maximum_jitter_interval"`
-	BackOffMaxTimeout              time.Duration `json:"back_off_max_timeout"`
-	DialerKeepAlive                time.Duration `json:"dialer_keep_alive"`
-	DialerTimeout                  time.Duration `json:"dialer_timeout"`
-	RequestRetryCount              int           `json:"request_retry_count"`
-	RequestTimeout                 time.Duration `json:"request_timeout"`
-	TransportExpectContinueTimeout time.Duration `json:"transport_expect_continue_timeout"`
-	TransportIdleTimeout           time.Duration `json:"transport_idle_timeout"`
-	TransportMaxIdleConnections    int           `json:"transport_max_idle_connections"`
-	TransportTLSHandshakeTimeout   time.Duration `json:"transport_tls_handshake_timeout"`
-	UserAgent                      string        `json:"user_agent"`
+	BackOffExponentFactor          float64              `json:"back_off_exponent_factor"`
+	BackOffInitialTimeout          time.Duration        `json:"back_off_initial_timeout"`
+	BackOffMaximumJitterInterval   time.Duration        `json:"back_off_maximum_jitter_interval"`
+	BackOffMaxTimeout              time.Duration        `json:"back_off_max_timeout"`
+	CacheBackend                   CacheBackend         `json:"-"`                            // Where cached rates are stored (defaults to MemoryCache)
+	CacheStaleWhileRevalidate      time.Duration        `json:"cache_stale_while_revalidate"` // Serve an expired rate while refreshing in the background
+	CacheTTL                       time.Duration        `json:"cache_ttl"`                    // How long a rate is cached (0 disables the cache)
+	CircuitBreakerCooldown         time.Duration        `json:"circuit_breaker_cooldown"`     // How long an open circuit skips the provider before a probe (defaults to 30s)
+	CircuitBreakerThreshold        int                  `json:"circuit_breaker_threshold"`    // Consecutive failures before a provider is skipped (0 disables)
+	CustomProviders                []RateProvider       `json:"-"`                            // Custom providers (added after any built-in providers)
+	DialerKeepAlive                time.Duration        `json:"dialer_keep_alive"`
+	DialerTimeout                  time.Duration        `json:"dialer_timeout"`
+	DisableCoalescing              bool                 `json:"disable_coalescing"`    // Disable collapsing concurrent identical rate requests
+	FiatRates                      FiatRateSource       `json:"-"`                     // Fiat rates for cross-conversion (defaults to Coin Paprika)
+	HedgeDelay                     time.Duration        `json:"hedge_delay"`           // Also request the next provider if a provider has not answered within this (0 is sequential fail-over)
+	Logger                         Logger               `json:"-"`                     // Structured logger for provider attempts, fallbacks and cache hits (IE: *slog.Logger)
+	MaxDeviationPercent            float64              `json:"max_deviation_percent"` // Reject providers that diverge from the median (or the first provider if there are two) by more than this (0 disables)
+	MaxRateAge                     time.Duration        `json:"max_rate_age"`          // Reject quotes older than this and fail-over (0 disables)
+	Metrics                        Metrics              `json:"-"`                     // Metrics hook for provider requests, fallbacks and the cache
+	MinimumQuorum                  int                  `json:"minimum_quorum"`        // Minimum number of agreeing providers (0 or 1 is a single provider)
+	ProviderOrdering               ProviderOrdering     `json:"provider_ordering"`     // Order the providers are tried in (pinned registration order by default, or adaptive)
+	ProviderWeights                map[string]float64   `json:"provider_weights"`      // Weights by provider name (AggregationWeightedMean)
+	RateLimits                     map[string]RateLimit `json:"rate_limits"`           // Client-side request limits by provider name (queue or fail fast)
+	RequestRetryCount              int                  `json:"request_retry_count"`
+	RequestTimeout                 time.Duration        `json:"request_timeout"`
+	Tracer                         Tracer               `json:"-"` // Tracer for spans around rate lookups and provider attempts
+	TransportExpectContinueTimeout time.Duration        `json:"transport_expect_continue_timeout"`
+	TransportIdleTimeout           time.Duration        `json:"transport_idle_timeout"`
+	TransportMaxIdleConnections    int                  `json:"transport_max_idle_connections"`
+	TransportTLSHandshakeTimeout   time.Duration        `json:"transport_tls_handshake_timeout"`
+	UserAgent                      string               `json:"user_agent"`
 }
 
 // ToWhatsOnChainOptions will convert the current options to WOC Options
@@ -65,6 +113,19 @@ func (c *ClientOptions) ToWhatsOnChainOptions() (options *whatsonchain.Options)
 	return
 }
 
+// quorum will return the minimum number of agreeing providers (at least one)
+func (c *ClientOptions) quorum() int {
+	if c.MinimumQuorum > 1 {
+		return c.MinimumQuorum
+	}
+	return 1
+}
+
+// useConsensus returns true if the consensus rules are enabled
+func (c *ClientOptions) useConsensus() bool {
+	return c.MinimumQuorum > 1 || c.MaxDeviationPercent > 0
+}
+
 // DefaultClientOptions will return a clientOptions struct with the default settings.
 // Useful for starting with the default and then modifying as needed
 func DefaultClientOptions() (clientOptions *ClientOptions) {
@@ -91,23 +152,73 @@ func NewClient(clientOptions *ClientOptions, customHTTPClient HTTPInterface,
 
 	c := new(Client)
 
-	// No providers? (Use the default set for now)
-	if len(providers) == 0 {
-		c.providers = defaultProviders
-	} else {
-		c.providers = providers
-	}
-
 	// Set default options if none are provided
 	if clientOptions == nil {
 		clientOptions = DefaultClientOptions()
 	}
+	c.options = clientOptions
+	c.logger = clientOptions.logger()
+	c.metrics = clientOptions.metrics()
+	c.tracer = clientOptions.tracer()
+
+	// Create the rate cache (if enabled)
+	c.cache = newRateCache(
+		clientOptions.CacheBackend, clientOptions.CacheTTL, clientOptions.CacheStaleWhileRevalidate,
+	)
+
+	// Create the circuit breakers (if enabled)
+	c.breakers = newCircuitBreakers(
+		clientOptions.CircuitBreakerThreshold, clientOptions.CircuitBreakerCooldown,
+	)
+
+	// Track the recent request results (rolling success rate for health checks)
+	c.health = newHealthStats()
+
+	// Create the rate limiters (Retry-After is always honored)
+	c.limiters = newRateLimiters(clientOptions.RateLimits)
+
+	// Collapse concurrent identical rate requests (unless disabled, limited by the RequestTimeout)
+	if !clientOptions.DisableCoalescing {
+		c.flights = newFlightGroup(clientOptions.RequestTimeout)
+	}
+
+	// Set the fiat rates (default is Coin Paprika)
+	if c.fiatRates = clientOptions.FiatRates; c.fiatRates == nil {
+		c.fiatRates = &coinPaprikaFiatRates{client: c}
+	}
+
+	// No providers? (Use the default set for now)
+	if len(providers) == 0 && len(clientOptions.CustomProviders) == 0 {
+		providers = defaultProviders
+	}
+
+	// Register the built-in providers (unknown providers are skipped)
+	for _, provider := range providers {
+		if rateProvider, err := newBuiltInProvider(c, provider); err == nil {
+			c.AddProvider(rateProvider)
+		}
+	}
+
+	// Register any custom providers
+	for _, rateProvider := range clientOptions.CustomProviders {
+		c.AddProvider(rateProvider)
+	}
 
 	// Create a client for Coin Paprika
 	c.coinPaprika = createPaprikaClient(
 		clientOptions, customHTTPClient,
 	)
 
+	// Create a client for Bitfinex
+	c.bitfinex = createBitfinexClient(
+		clientOptions, customHTTPClient,
+	)
+
+	// Create a client for CoinGecko
+	c.coinGecko = createGeckoClient(
+		clientOptions, customHTTPClient,
+	)
+
 	// Create a client for WhatsOnChain
 	c.whatsOnChain = whatsonchain.NewClient(
 		whatsonchain.NetworkMain, clientOptions.ToWhatsOnChainOptions(), customHTTPClient,
@@ -116,9 +227,73 @@ func NewClient(clientOptions *ClientOptions, customHTTPClient HTTPInterface,
 	return c
 }
 
-// Providers is the list of providers
-func (c *Client) Providers() []Provider {
-	return c.providers
+// Providers is the list of providers (custom providers are reported as ProviderCustom)
+func (c *Client) Providers() (providers []Provider) {
+	for _, rateProvider := range c.RateProviders() {
+		providers = append(providers, providerType(rateProvider))
+	}
+	return
+}
+
+// RateProviders will return a copy of the registry of providers (in order for fail-over)
+func (c *Client) RateProviders() []RateProvider {
+	c.providersMu.RLock()
+	defer c.providersMu.RUnlock()
+	return append([]RateProvider(nil), c.rateProviders...)
+}
+
+// backgroundContext will return a context for background requests (limited by the RequestTimeout)
+func (c *Client) backgroundContext() (context.Context, context.CancelFunc) {
+	if c.options.RequestTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.options.RequestTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// providersFor will return the providers that support the given currency (in order for fail-over,
+// see ClientOptions.ProviderOrdering), from a snapshot of the registry
+func (c *Client) providersFor(currency Currency) (providers []RateProvider) {
+	c.providersMu.RLock()
+	for _, rateProvider := range c.rateProviders {
+		if rateProvider.SupportsCurrency(currency) {
+			providers = append(providers, rateProvider)
+		}
+	}
+	c.providersMu.RUnlock()
+	return c.orderProviders(providers)
+}
+
+// AddProvider will add a provider to the end of the registry (safe to call while requests are running)
+func (c *Client) AddProvider(provider RateProvider) {
+	if provider != nil {
+		c.providersMu.Lock()
+		c.rateProviders = append(c.rateProviders, provider)
+		c.providersMu.Unlock()
+	}
+}
+
+// Bitfinex will return the client
+func (c *Client) Bitfinex() BitfinexInterface {
+	return c.bitfinex
+}
+
+// SetBitfinex will set the client
+func (c *Client) SetBitfinex(client BitfinexInterface) {
+	if client != nil {
+		c.bitfinex = client
+	}
+}
+
+// CoinGecko will return the client
+func (c *Client) CoinGecko() CoinGeckoInterface {
+	return c.coinGecko
+}
+
+// SetCoinGecko will set the client
+func (c *Client) SetCoinGecko(client CoinGeckoInterface) {
+	if client != nil {
+		c.coinGecko = client
+	}
 }
 
 // CoinPaprika will return the client
@@ -133,6 +308,18 @@ func (c *Client) SetCoinPaprika(client CoinPaprikaInterface) {
 	}
 }
 
+// FiatRates will return the fiat rate source
+func (c *Client) FiatRates() FiatRateSource {
+	return c.fiatRates
+}
+
+// SetFiatRates will set the fiat rate source
+func (c *Client) SetFiatRates(fiatRates FiatRateSource) {
+	if fiatRates != nil {
+		c.fiatRates = fiatRates
+	}
+}
+
 // WhatsOnChain will return the client
 func (c *Client) WhatsOnChain() whatsonchain.ChainService {
 	return c.whatsOnChain
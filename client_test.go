@@ -1,6 +1,7 @@
 package bsvrates
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
@@ -91,3 +92,53 @@ func TestClientOptions_ToWhatsOnChainOptions(t *testing.T) {
 		assert.Equal(t, 5*time.Second, whatsOnChainOptions.TransportTLSHandshakeTimeout)
 	})
 }
+
+// TestClientOptions_Nop will test the no-op defaults and the options that replace them
+func TestClientOptions_Nop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		options := DefaultClientOptions()
+		assert.Equal(t, nopLogger{}, options.logger())
+		assert.Equal(t, nopMetrics{}, options.metrics())
+		assert.Equal(t, nopTracer{}, options.tracer())
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		assert.Equal(t, logger, options.logger())
+
+		metrics := newMockMetrics()
+		options.Metrics = metrics
+		assert.Equal(t, metrics, options.metrics())
+
+		tracer := &mockTracer{}
+		options.Tracer = tracer
+		assert.Equal(t, tracer, options.tracer())
+	})
+
+	t.Run("no-op implementations", func(t *testing.T) {
+		ctx := context.Background()
+		assert.NotPanics(t, func() {
+			var logger Logger = nopLogger{}
+			logger.DebugContext(ctx, "test")
+			logger.ErrorContext(ctx, "test")
+			logger.InfoContext(ctx, "test")
+			logger.WarnContext(ctx, "test")
+
+			var metrics Metrics = nopMetrics{}
+			metrics.ObserveCache("test", CurrencyDollars, CacheHit)
+			metrics.ObserveFallback("test", CurrencyDollars)
+			metrics.ObserveProviderRequest("test", CurrencyDollars, 0, nil, time.Second)
+		})
+
+		spanCtx, span := nopTracer{}.Start(ctx, spanRate)
+		assert.Equal(t, ctx, spanCtx)
+		assert.NotPanics(t, func() {
+			span.SetAttributes(Attribute{Key: "test", Value: 1})
+			span.End(nil)
+		})
+	})
+}
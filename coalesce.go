@@ -0,0 +1,141 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightCall is an in-flight quote request shared by all the callers waiting on it
+type flightCall struct {
+	cancel  context.CancelFunc // Cancels the upstream request (when all callers have left)
+	ctx     context.Context    // Context of the upstream request (done once it times out or is cancelled)
+	done    chan struct{}      // Closed when the upstream request is complete
+	err     error              // Error from the upstream request
+	quote   *Quote             // Quote from the upstream request
+	waiters int                // Number of callers waiting on the request
+}
+
+// flightGroup collapses duplicate in-flight rate requests into a single upstream request
+type flightGroup struct {
+	calls   map[string]*flightCall // In-flight requests (keyed by provider and currency)
+	mu      sync.Mutex             // Guards the calls map and waiters
+	timeout time.Duration          // Limit for an upstream request (0 is no limit)
+}
+
+// newFlightGroup will return a new flight group (upstream requests are limited by the timeout, if set)
+func newFlightGroup(timeout time.Duration) *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall), timeout: timeout}
+}
+
+// do will run the fetch for the key, or wait on the in-flight fetch if there is one.
+//
+// The upstream request does not use the caller's cancellation (only its values), so one
+// caller cancelling does not fail the other callers. A caller whose context is done returns
+// right away, and the upstream request is cancelled once every caller has left.
+//
+// The upstream request is limited by the group timeout instead. Once it times out, the callers
+// return the timeout error (even if the fetch ignores it) and the next caller starts a new request
+func (g *flightGroup) do(ctx context.Context, key string,
+	fetch func(ctx context.Context) (*Quote, error)) (quote *Quote, err error) {
+
+	// Join the in-flight request or start a new one
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		if g.timeout > 0 {
+			call.ctx, call.cancel = context.WithTimeout(detachedContext{parent: ctx}, g.timeout)
+		} else {
+			call.ctx, call.cancel = context.WithCancel(detachedContext{parent: ctx})
+		}
+		g.calls[key] = call
+		go g.run(key, call, fetch)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	// Wait for the result (or the caller to leave)
+	select {
+	case <-call.done:
+		return call.quote, call.err
+	case <-ctx.Done():
+		g.leave(key, call)
+		return nil, ctx.Err()
+	case <-call.ctx.Done():
+		select {
+		case <-call.done:
+			return call.quote, call.err
+		default:
+		}
+		g.forget(key, call)
+		return nil, call.ctx.Err()
+	}
+}
+
+// run will fire the upstream request and share the result
+// (done is closed before the context is cancelled, so the callers always see the result)
+func (g *flightGroup) run(key string, call *flightCall, fetch func(ctx context.Context) (*Quote, error)) {
+	call.quote, call.err = fetch(call.ctx)
+	g.forget(key, call)
+	close(call.done)
+	call.cancel()
+}
+
+// forget will remove the request from the in-flight requests (so the next caller starts a new one)
+func (g *flightGroup) forget(key string, call *flightCall) {
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+}
+
+// leave will remove a waiting caller (cancelling the upstream request if it was the last)
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call.waiters--; call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// detachedContext keeps the values of the parent context but not the cancellation or deadline
+type detachedContext struct {
+	parent context.Context
+}
+
+// Deadline returns no deadline
+func (d detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+// Done returns nil (never done)
+func (d detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err returns nil (never cancelled)
+func (d detachedContext) Err() error {
+	return nil
+}
+
+// Value returns the value from the parent context
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// fetchProviderQuote will get the quote from the provider
+// (concurrent identical requests are collapsed into one unless coalescing is disabled)
+func (c *Client) fetchProviderQuote(ctx context.Context, provider RateProvider, currency Currency) (*Quote, error) {
+	if c.flights == nil {
+		return c.requestQuote(ctx, provider, currency)
+	}
+	return c.flights.do(ctx, cacheKey(provider, currency), func(ctx context.Context) (*Quote, error) {
+		return c.requestQuote(ctx, provider, currency)
+	})
+}
@@ -0,0 +1,191 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waiters will return the number of callers waiting on the in-flight request
+func (g *flightGroup) waiters(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
+
+// TestClient_GetRate_Coalescing will test collapsing concurrent identical rate requests
+func TestClient_GetRate_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent requests share one upstream request", func(t *testing.T) {
+		provider := newMockSlowProvider()
+		client := newMockClient(newMockOptions(provider), nil, nil).(*Client)
+		key := cacheKey(provider, CurrencyDollars)
+
+		var wg sync.WaitGroup
+		var succeeded int64
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if rate, _, err := client.GetRate(context.Background(), CurrencyDollars); err == nil && rate == 150 {
+					atomic.AddInt64(&succeeded, 1)
+				}
+			}()
+		}
+
+		assert.Eventually(t, func() bool {
+			return client.flights.waiters(key) == 50
+		}, time.Second, time.Millisecond)
+		close(provider.release)
+		wg.Wait()
+
+		assert.Equal(t, int64(50), atomic.LoadInt64(&succeeded))
+		assert.Equal(t, int64(1), atomic.LoadInt64(&provider.calls))
+	})
+
+	t.Run("one caller cancels, the others still get the rate", func(t *testing.T) {
+		provider := newMockSlowProvider()
+		client := newMockClient(newMockOptions(provider), nil, nil).(*Client)
+		key := cacheKey(provider, CurrencyDollars)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelledErr := make(chan error, 1)
+		go func() {
+			_, _, err := client.GetRate(ctx, CurrencyDollars)
+			cancelledErr <- err
+		}()
+
+		var rate float64
+		var err error
+		done := make(chan struct{})
+		go func() {
+			rate, _, err = client.GetRate(context.Background(), CurrencyDollars)
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return client.flights.waiters(key) == 2
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-cancelledErr, context.Canceled)
+
+		close(provider.release)
+		<-done
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, int64(1), atomic.LoadInt64(&provider.calls))
+		assert.Equal(t, int64(0), atomic.LoadInt64(&provider.cancelled))
+	})
+
+	t.Run("all callers cancel, the upstream request is cancelled", func(t *testing.T) {
+		provider := newMockSlowProvider()
+		client := newMockClient(newMockOptions(provider), nil, nil).(*Client)
+		key := cacheKey(provider, CurrencyDollars)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() {
+			_, _, err := client.GetRate(ctx, CurrencyDollars)
+			errs <- err
+		}()
+
+		assert.Eventually(t, func() bool {
+			return client.flights.waiters(key) == 1
+		}, time.Second, time.Millisecond)
+		cancel()
+
+		assert.ErrorIs(t, <-errs, context.Canceled)
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt64(&provider.cancelled) == 1
+		}, time.Second, time.Millisecond)
+
+		// A new caller starts a new upstream request
+		close(provider.release)
+		rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, int64(2), atomic.LoadInt64(&provider.calls))
+	})
+
+	t.Run("upstream request is limited by the request timeout", func(t *testing.T) {
+		provider := newMockSlowProvider()
+		provider.hang = true
+		defer close(provider.release)
+		options := newMockOptions(provider)
+		options.RequestTimeout = 100 * time.Millisecond
+		client := newMockClient(options, nil, nil).(*Client)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+
+		// The next caller starts a new upstream request
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int64(2), atomic.LoadInt64(&provider.calls))
+	})
+
+	t.Run("coalescing disabled", func(t *testing.T) {
+		provider := newMockSlowProvider()
+		options := newMockOptions(provider)
+		options.DisableCoalescing = true
+		client := newMockClient(options, nil, nil).(*Client)
+		assert.Nil(t, client.flights)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, _ = client.GetRate(context.Background(), CurrencyDollars)
+			}()
+		}
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt64(&provider.calls) == 5
+		}, time.Second, time.Millisecond)
+		close(provider.release)
+		wg.Wait()
+	})
+}
+
+// TestDetachedContext will test the detached context
+func TestDetachedContext(t *testing.T) {
+	t.Parallel()
+
+	type contextKey string
+	parent, cancel := context.WithTimeout(
+		context.WithValue(context.Background(), contextKey("key"), "value"), time.Millisecond,
+	)
+	defer cancel()
+	<-parent.Done()
+
+	detached := detachedContext{parent: parent}
+	deadline, ok := detached.Deadline()
+	assert.False(t, ok)
+	assert.True(t, deadline.IsZero())
+	assert.Nil(t, detached.Done())
+	assert.NoError(t, detached.Err())
+	assert.Equal(t, "value", detached.Value(contextKey("key")))
+}
@@ -0,0 +1,309 @@
+package bsvrates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// coinGeckoBaseURL is the main url for the service
+const coinGeckoBaseURL = "https://api.coingecko.com/api/v3/"
+
+// Accepted currencies (vs_currencies) for CoinGecko
+var acceptedCurrenciesCoinGecko = []string{
+	"aud",
+	"brl",
+	"cad",
+	"chf",
+	"cny",
+	"eur",
+	"gbp",
+	"jpy",
+	"krw",
+	"mxn",
+	"nok",
+	"nzd",
+	"pln",
+	"rub",
+	"sek",
+	"try",
+	"twd",
+	usd,
+	"zar",
+}
+
+// GeckoClient is the client for CoinGecko
+type GeckoClient struct {
+	BaseURL    string        // url of the api (defaults to the public api, IE: a Pro api or a local stub server)
+	HTTPClient HTTPInterface // carries out the http operations (heimdall client)
+	Logger     Logger        // logs failed requests (optional)
+	UserAgent  string
+}
+
+// PingResponse is the result returned from CoinGecko ping request
+type PingResponse struct {
+	GeckoSays   string       `json:"gecko_says"`
+	LastRequest *lastRequest `json:"-"` // is the raw information from the last request
+}
+
+// SimplePriceResponse is the result returned from CoinGecko simple price request (for a single coin)
+type SimplePriceResponse struct {
+	CoinID        string             `json:"coin_id"`
+	LastRequest   *lastRequest       `json:"-"` // is the raw information from the last request
+	LastUpdatedAt int64              `json:"last_updated_at"`
+	Prices        map[string]float64 `json:"prices"`      // Price by currency (IE: usd)
+	Volumes24h    map[string]float64 `json:"volumes_24h"` // 24 hour volume by currency (IE: usd)
+}
+
+// MarketChartResponse is the result returned from CoinGecko market chart range request
+type MarketChartResponse struct {
+	LastRequest  *lastRequest  `json:"-"` // is the raw information from the last request
+	MarketCaps   []*ChartPoint `json:"market_caps"`
+	Prices       []*ChartPoint `json:"prices"`
+	TotalVolumes []*ChartPoint `json:"total_volumes"`
+}
+
+// ChartPoint is a single value in a CoinGecko market chart (decoded from [timestamp, value])
+type ChartPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// OHLCResponse is the result returned from CoinGecko OHLC request
+type OHLCResponse struct {
+	Candles     []*OHLC      `json:"candles"`
+	LastRequest *lastRequest `json:"-"` // is the raw information from the last request
+}
+
+// OHLC is a single CoinGecko candle (decoded from [timestamp, open, high, low, close])
+type OHLC struct {
+	Close     float64   `json:"close"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Open      float64   `json:"open"`
+	Timestamp time.Time `json:"timestamp"` // Close time of the candle
+}
+
+// UnmarshalJSON will decode the chart point from [timestamp in milliseconds, value]
+func (p *ChartPoint) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	} else if len(values) != 2 {
+		return fmt.Errorf("expected 2 values in a chart point, got %d", len(values))
+	}
+	p.Timestamp = time.UnixMilli(int64(values[0])).UTC()
+	p.Value = values[1]
+	return nil
+}
+
+// UnmarshalJSON will decode the candle from [timestamp in milliseconds, open, high, low, close]
+func (o *OHLC) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	} else if len(values) != 5 {
+		return fmt.Errorf("expected 5 values in a candle, got %d", len(values))
+	}
+	o.Timestamp = time.UnixMilli(int64(values[0])).UTC()
+	o.Open, o.High, o.Low, o.Close = values[1], values[2], values[3], values[4]
+	return nil
+}
+
+// createGeckoClient will make a new http client based on the options provided
+func createGeckoClient(options *ClientOptions, customHTTPClient HTTPInterface) CoinGeckoInterface {
+
+	// Create a client
+	c := &GeckoClient{BaseURL: coinGeckoBaseURL}
+
+	// Set options (either default or user modified)
+	if options == nil {
+		options = DefaultClientOptions()
+	}
+
+	// Set the user agent and logger
+	c.UserAgent = options.UserAgent
+	c.Logger = options.Logger
+
+	// Is there a custom HTTP client to use?
+	if c.HTTPClient = customHTTPClient; c.HTTPClient == nil {
+		c.HTTPClient = createHTTPClient(options)
+	}
+	return c
+}
+
+// IsAcceptedCurrency will check if the currency is accepted
+func (g *GeckoClient) IsAcceptedCurrency(currency string) bool {
+	currency = strings.ToLower(currency)
+	for _, accepted := range acceptedCurrenciesCoinGecko {
+		if accepted == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// Ping returns a response of the CoinGecko server status (a cheap request that is used for health checks)
+//
+// See: https://docs.coingecko.com/reference/ping-server
+func (g *GeckoClient) Ping(ctx context.Context) (response *PingResponse, err error) {
+	response = &PingResponse{LastRequest: new(lastRequest)}
+	err = g.request(ctx, "ping", response.LastRequest, response)
+	return
+}
+
+// GetSimplePrice returns a response of the prices of the coin in each of the currencies from CoinGecko
+// (including the 24 hour volume and the last updated time)
+//
+// See: https://docs.coingecko.com/reference/simple-price
+func (g *GeckoClient) GetSimplePrice(ctx context.Context, coinID string,
+	currencies ...string) (response *SimplePriceResponse, err error) {
+
+	// Set the api url
+	// simple/price?ids=bitcoin-cash-sv&vs_currencies=usd,eur&include_24hr_vol=true&include_last_updated_at=true
+	path := fmt.Sprintf(
+		"simple/price?ids=%s&vs_currencies=%s&include_24hr_vol=true&include_last_updated_at=true",
+		url.QueryEscape(coinID), url.QueryEscape(strings.ToLower(strings.Join(currencies, ","))),
+	)
+
+	// Fire the request
+	response = &SimplePriceResponse{
+		CoinID:      coinID,
+		LastRequest: new(lastRequest),
+		Prices:      make(map[string]float64),
+		Volumes24h:  make(map[string]float64),
+	}
+	var coins map[string]map[string]float64
+	if err = g.request(ctx, path, response.LastRequest, &coins); err != nil {
+		return
+	}
+
+	// Split the values into prices and volumes
+	values, ok := coins[coinID]
+	if !ok {
+		err = newKindError(ErrDecode, "no prices returned for [%s]", coinID)
+		return
+	}
+	for key, value := range values {
+		switch {
+		case key == "last_updated_at":
+			response.LastUpdatedAt = int64(value)
+		case strings.HasSuffix(key, "_24h_vol"):
+			response.Volumes24h[strings.TrimSuffix(key, "_24h_vol")] = value
+		default:
+			response.Prices[key] = value
+		}
+	}
+	return
+}
+
+// GetMarketChartRange returns a response of the historical prices, market caps and volumes
+// of the coin between the times from CoinGecko (the granularity is set by CoinGecko based on the range)
+//
+// See: https://docs.coingecko.com/reference/coins-id-market-chart-range
+func (g *GeckoClient) GetMarketChartRange(ctx context.Context, coinID, currency string,
+	from, to time.Time) (response *MarketChartResponse, err error) {
+
+	// Set the api url
+	// coins/bitcoin-cash-sv/market_chart/range?vs_currency=usd&from=1609462861&to=1609549261
+	path := fmt.Sprintf(
+		"coins/%s/market_chart/range?vs_currency=%s&from=%d&to=%d",
+		url.PathEscape(coinID), url.QueryEscape(strings.ToLower(currency)), from.Unix(), to.Unix(),
+	)
+
+	// Fire the request
+	response = &MarketChartResponse{LastRequest: new(lastRequest)}
+	err = g.request(ctx, path, response.LastRequest, response)
+	return
+}
+
+// GetOHLC returns a response of the candles (open, high, low, close) of the coin for the
+// last number of days from CoinGecko (IE: 1, 7, 14, 30, 90, 180, 365)
+//
+// See: https://docs.coingecko.com/reference/coins-id-ohlc
+func (g *GeckoClient) GetOHLC(ctx context.Context, coinID, currency string,
+	days int) (response *OHLCResponse, err error) {
+
+	// Set the api url
+	// coins/bitcoin-cash-sv/ohlc?vs_currency=usd&days=1
+	path := fmt.Sprintf(
+		"coins/%s/ohlc?vs_currency=%s&days=%d",
+		url.PathEscape(coinID), url.QueryEscape(strings.ToLower(currency)), days,
+	)
+
+	// Fire the request
+	response = &OHLCResponse{LastRequest: new(lastRequest)}
+	err = g.request(ctx, path, response.LastRequest, &response.Candles)
+	return
+}
+
+// request will fire a GET request to CoinGecko and decode the response
+// (the last request is recorded and failures are logged)
+func (g *GeckoClient) request(ctx context.Context, path string, request *lastRequest,
+	response interface{}) (err error) {
+
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			g.logResponseError(ctx, request, err)
+		}
+	}()
+
+	// Set the api url
+	baseURL := g.BaseURL
+	if len(baseURL) == 0 {
+		baseURL = coinGeckoBaseURL
+	}
+	return requestJSON(ctx, g.HTTPClient, g.UserAgent, strings.TrimSuffix(baseURL, "/")+"/"+path, request, response)
+}
+
+// requestJSON will fire a GET request and decode the JSON response (the last request is recorded)
+func requestJSON(ctx context.Context, httpClient HTTPInterface, userAgent, reqURL string,
+	request *lastRequest, response interface{}) (err error) {
+
+	// Record the request
+	request.Method = http.MethodGet
+	request.URL = reqURL
+
+	// Start the request
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(
+		ctx, http.MethodGet, reqURL, nil,
+	); err != nil {
+		return
+	}
+
+	// Set the headers (user agent is in case they block default Go user agents)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	// Fire the request
+	var resp *http.Response
+	if resp, err = httpClient.Do(req); err != nil {
+		if resp != nil {
+			request.StatusCode = resp.StatusCode
+		}
+		return
+	}
+
+	// Close the body
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Check the status code
+	if request.StatusCode = resp.StatusCode; resp.StatusCode != http.StatusOK {
+		err = newProviderHTTPError(http.MethodGet, reqURL, resp)
+		return
+	}
+
+	// Try and decode the response
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		err = newDecodeError(err)
+	}
+	return
+}
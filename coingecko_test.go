@@ -0,0 +1,226 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGeckoClient_IsAcceptedCurrency will test the method IsAcceptedCurrency()
+func TestGeckoClient_IsAcceptedCurrency(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		currency         string
+		expectedAccepted bool
+	}{
+		{"usd", true},
+		{"USD", true},
+		{"eur", true},
+		{"zar", true},
+		{"bsv", false},
+		{"", false},
+	}
+	client := createGeckoClient(nil, nil)
+	for _, test := range tests {
+		assert.Equal(t, test.expectedAccepted, client.IsAcceptedCurrency(test.currency), test.currency)
+	}
+}
+
+// TestGeckoClient_Ping will test the method Ping()
+func TestGeckoClient_Ping(t *testing.T) {
+	t.Parallel()
+
+	server := newMockGeckoServer()
+	defer server.Close()
+
+	response, err := newMockGeckoClient(server).Ping(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "(V3) To the Moon!", response.GeckoSays)
+	assert.Equal(t, http.StatusOK, response.LastRequest.StatusCode)
+	assert.Equal(t, server.URL+"/ping", response.LastRequest.URL)
+}
+
+// TestGeckoClient_GetSimplePrice will test the method GetSimplePrice()
+func TestGeckoClient_GetSimplePrice(t *testing.T) {
+	t.Parallel()
+
+	server := newMockGeckoServer()
+	defer server.Close()
+	client := newMockGeckoClient(server)
+
+	t.Run("multiple currencies", func(t *testing.T) {
+		response, err := client.GetSimplePrice(context.Background(), CoinGeckoCoinID, "USD", "eur")
+		assert.NoError(t, err)
+		assert.Equal(t, CoinGeckoCoinID, response.CoinID)
+		assert.Equal(t, map[string]float64{"usd": 48.12, "eur": 44.31}, response.Prices)
+		assert.Equal(t, map[string]float64{"usd": 21498730.95, "eur": 19798412.22}, response.Volumes24h)
+		assert.Equal(t, int64(1700000000), response.LastUpdatedAt)
+		assert.Contains(t, response.LastRequest.URL, "vs_currencies=usd%2Ceur")
+	})
+
+	t.Run("unknown coin", func(t *testing.T) {
+		_, err := client.GetSimplePrice(context.Background(), "unknown", usd)
+		assert.EqualError(t, err, "no prices returned for [unknown]")
+		assert.True(t, errors.Is(err, ErrDecode))
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		response, err := client.GetSimplePrice(context.Background(), CoinGeckoCoinID, "krw")
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.Equal(t, http.StatusTooManyRequests, response.LastRequest.StatusCode)
+		assert.Equal(t, 30*time.Second, errorRetryAfter(err))
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		_, err := client.GetSimplePrice(context.Background(), CoinGeckoCoinID, "jpy")
+		assert.True(t, errors.Is(err, ErrDecode))
+	})
+
+	t.Run("request failed", func(t *testing.T) {
+		down := newMockGeckoServer()
+		down.Close()
+		logger := &mockLogger{}
+		downClient := newMockGeckoClient(down)
+		downClient.Logger = logger
+
+		_, err := downClient.GetSimplePrice(context.Background(), CoinGeckoCoinID, usd)
+		assert.Error(t, err)
+		assert.NotNil(t, logger.find("warn", "coin gecko request failed"))
+	})
+}
+
+// TestGeckoClient_GetMarketChartRange will test the method GetMarketChartRange()
+func TestGeckoClient_GetMarketChartRange(t *testing.T) {
+	t.Parallel()
+
+	server := newMockGeckoServer()
+	defer server.Close()
+
+	response, err := newMockGeckoClient(server).GetMarketChartRange(
+		context.Background(), CoinGeckoCoinID, "USD",
+		time.Unix(1609462861, 0), time.Unix(1609549261, 0),
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, response.LastRequest.URL, "vs_currency=usd&from=1609462861&to=1609549261")
+	if assert.Equal(t, 2, len(response.Prices)) {
+		assert.Equal(t, time.Unix(1609462861, 0).UTC(), response.Prices[0].Timestamp)
+		assert.Equal(t, 162.61, response.Prices[0].Value)
+	}
+	assert.Equal(t, 2, len(response.MarketCaps))
+	assert.Equal(t, 2, len(response.TotalVolumes))
+	assert.Equal(t, 498003187.4, response.TotalVolumes[1].Value)
+}
+
+// TestGeckoClient_GetOHLC will test the method GetOHLC()
+func TestGeckoClient_GetOHLC(t *testing.T) {
+	t.Parallel()
+
+	server := newMockGeckoServer()
+	defer server.Close()
+
+	response, err := newMockGeckoClient(server).GetOHLC(context.Background(), CoinGeckoCoinID, usd, 1)
+	assert.NoError(t, err)
+	assert.Contains(t, response.LastRequest.URL, "ohlc?vs_currency=usd&days=1")
+	if assert.Equal(t, 2, len(response.Candles)) {
+		assert.Equal(t, &OHLC{
+			Close:     162.6,
+			High:      163.5,
+			Low:       161.8,
+			Open:      162.1,
+			Timestamp: time.Unix(1609462800, 0).UTC(),
+		}, response.Candles[0])
+	}
+}
+
+// TestChartPoint_UnmarshalJSON will test the UnmarshalJSON() of ChartPoint and OHLC
+func TestChartPoint_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		data          string
+		expectedPoint bool
+		expectedOHLC  bool
+	}{
+		{`[1609462800000,162.1]`, true, false},
+		{`[1609462800000,162.1,163.5,161.8,162.6]`, false, true},
+		{`[1609462800000]`, false, false},
+		{`{"price":162.1}`, false, false},
+		{`["bad","values"]`, false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedPoint, new(ChartPoint).UnmarshalJSON([]byte(test.data)) == nil, test.data)
+		assert.Equal(t, test.expectedOHLC, new(OHLC).UnmarshalJSON([]byte(test.data)) == nil, test.data)
+	}
+}
+
+// TestClient_CoinGecko will test the CoinGecko provider
+func TestClient_CoinGecko(t *testing.T) {
+	t.Parallel()
+
+	server := newMockGeckoServer()
+	defer server.Close()
+
+	// newGeckoClient will return a client using only CoinGecko (against the stub server)
+	newGeckoClient := func() ClientInterface {
+		client := NewClient(nil, nil, ProviderCoinGecko)
+		client.SetCoinGecko(newMockGeckoClient(server))
+		return client
+	}
+
+	t.Run("set and get the client", func(t *testing.T) {
+		client := NewClient(nil, nil)
+		assert.NotNil(t, client.CoinGecko())
+		client.SetCoinGecko(nil)
+		assert.NotNil(t, client.CoinGecko())
+		assert.Equal(t, []Provider{ProviderCoinPaprika, ProviderWhatsOnChain}, client.Providers())
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		result, err := newGeckoClient().GetRateResult(context.Background(), CurrencyEuro)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderCoinGecko, result.Provider)
+		assert.Equal(t, "CoinGecko", result.ProviderName)
+		assert.Equal(t, "44.31", result.Rate.String())
+		assert.Equal(t, "19798412.22", result.Volume24h.String())
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), result.QuotedAt)
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+		satoshis, providerUsed, err := newGeckoClient().GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderCoinGecko, providerUsed)
+		assert.Equal(t, int64(2078138), satoshis)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		result, err := newGeckoClient().GetRateResult(context.Background(), CurrencySouthKoreanWon)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrRateLimited))
+
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) {
+			assert.Equal(t, http.StatusTooManyRequests, errs[0].StatusCode)
+			assert.Equal(t, ProviderCoinGecko, errs[0].Provider)
+		}
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		provider := &coinGeckoProvider{client: newGeckoClient().(*Client)}
+		assert.True(t, provider.SupportsCurrency(CurrencyJapaneseYen))
+		assert.False(t, provider.SupportsCurrency(CurrencyBitcoin))
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		report := newGeckoClient().HealthCheck(context.Background())
+		assert.True(t, report.Healthy)
+		if assert.Equal(t, 1, len(report.Providers)) {
+			assert.Equal(t, ProviderCoinGecko, report.Providers[0].Provider)
+			assert.Equal(t, HealthHealthy, report.Providers[0].Status)
+		}
+	})
+}
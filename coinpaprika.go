@@ -76,6 +76,16 @@ type TickerResponse struct {
 	TotalSupply       int64        `json:"total_supply"`
 }
 
+// GlobalResponse is the result returned from Coin Paprika global market request
+type GlobalResponse struct {
+	BitcoinDominancePercentage float64      `json:"bitcoin_dominance_percentage"`
+	CryptocurrenciesNumber     int          `json:"cryptocurrencies_number"`
+	LastRequest                *lastRequest `json:"-"` // is the raw information from the last request
+	LastUpdated                int64        `json:"last_updated"`
+	MarketCapUSD               int64        `json:"market_cap_usd"`
+	Volume24hUSD               int64        `json:"volume_24h_usd"`
+}
+
 // currency is the parent struct of a quote for a Ticker request
 type currency struct {
 	USD *quote `json:"USD"`
@@ -105,6 +115,7 @@ var acceptedCurrenciesCoinPaprika = []string{
 	"mxn",
 	"new",
 	"nok",
+	"nzd",
 	"pln",
 	"rub",
 	"sek",
@@ -123,7 +134,7 @@ func (p PriceConversionResponse) GetSatoshi() (satoshi int64, err error) {
 	case math.IsInf(p.Price, 1):
 		fallthrough
 	case math.IsInf(p.Price, -1):
-		return 0, fmt.Errorf("invalid price conversion")
+		return 0, newKindError(ErrDecode, "invalid price conversion")
 	}
 
 	satoshiDecimal := decimal.NewFromFloat(p.Price).Mul(decimal.NewFromInt(1e8))
@@ -136,6 +147,7 @@ func (p PriceConversionResponse) GetSatoshi() (satoshi int64, err error) {
 // PaprikaClient is the client for Coin Paprika
 type PaprikaClient struct {
 	HTTPClient HTTPInterface // carries out the http operations (heimdall client)
+	Logger     Logger        // logs failed requests (optional)
 	UserAgent  string
 }
 
@@ -158,14 +170,19 @@ func createPaprikaClient(options *ClientOptions, customHTTPClient HTTPInterface)
 		options = DefaultClientOptions()
 	}
 
-	// Set the user agent
+	// Set the user agent and logger
 	c.UserAgent = options.UserAgent
+	c.Logger = options.Logger
 
 	// Is there a custom HTTP client to use?
-	if customHTTPClient != nil {
-		c.HTTPClient = customHTTPClient
-		return c
+	if c.HTTPClient = customHTTPClient; c.HTTPClient == nil {
+		c.HTTPClient = createHTTPClient(options)
 	}
+	return c
+}
+
+// createHTTPClient will make a new http client (heimdall) based on the options provided
+func createHTTPClient(options *ClientOptions) HTTPInterface {
 
 	// dial is the net dialer for clientDefaultTransport
 	dial := &net.Dialer{KeepAlive: options.DialerKeepAlive, Timeout: options.DialerTimeout}
@@ -182,26 +199,8 @@ func createPaprikaClient(options *ClientOptions, customHTTPClient HTTPInterface)
 
 	// Determine the strategy for the http client (no retry enabled)
 	if options.RequestRetryCount <= 0 {
-		c.HTTPClient = httpclient.NewClient(
-			httpclient.WithHTTPTimeout(options.RequestTimeout),
-			httpclient.WithHTTPClient(&http.Client{
-				Transport: clientDefaultTransport,
-				Timeout:   options.RequestTimeout,
-			}),
-		)
-	} else { // Retry enabled
-		// Create exponential back-off
-		backOff := heimdall.NewExponentialBackoff(
-			options.BackOffInitialTimeout,
-			options.BackOffMaxTimeout,
-			options.BackOffExponentFactor,
-			options.BackOffMaximumJitterInterval,
-		)
-
-		c.HTTPClient = httpclient.NewClient(
+		return httpclient.NewClient(
 			httpclient.WithHTTPTimeout(options.RequestTimeout),
-			httpclient.WithRetrier(heimdall.NewRetrier(backOff)),
-			httpclient.WithRetryCount(options.RequestRetryCount),
 			httpclient.WithHTTPClient(&http.Client{
 				Transport: clientDefaultTransport,
 				Timeout:   options.RequestTimeout,
@@ -209,7 +208,22 @@ func createPaprikaClient(options *ClientOptions, customHTTPClient HTTPInterface)
 		)
 	}
 
-	return c
+	// Retry enabled (create exponential back-off)
+	backOff := heimdall.NewExponentialBackoff(
+		options.BackOffInitialTimeout,
+		options.BackOffMaxTimeout,
+		options.BackOffExponentFactor,
+		options.BackOffMaximumJitterInterval,
+	)
+	return httpclient.NewClient(
+		httpclient.WithHTTPTimeout(options.RequestTimeout),
+		httpclient.WithRetrier(heimdall.NewRetrier(backOff)),
+		httpclient.WithRetryCount(options.RequestRetryCount),
+		httpclient.WithHTTPClient(&http.Client{
+			Transport: clientDefaultTransport,
+			Timeout:   options.RequestTimeout,
+		}),
+	)
 }
 
 // GetBaseAmountAndCurrencyID will return an ID and default amount
@@ -239,7 +253,7 @@ func (p *PaprikaClient) GetBaseAmountAndCurrencyID(currency string, amount float
 		return JPYCurrencyID, amount
 	case "mxn":
 		return MXNCurrencyID, amount
-	case "new":
+	case "new", "nzd":
 		return NEWCurrencyID, amount
 	case "nok":
 		return NOKCurrencyID, amount
@@ -304,6 +318,13 @@ func (p *PaprikaClient) GetPriceConversion(ctx context.Context, baseCurrencyID,
 	response.LastRequest.Method = http.MethodGet
 	response.LastRequest.URL = reqURL
 
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			p.logResponseError(ctx, response.LastRequest, err)
+		}
+	}()
+
 	// Fire the request
 	var resp *http.Response
 	if resp, err = p.HTTPClient.Do(req); err != nil {
@@ -323,12 +344,14 @@ func (p *PaprikaClient) GetPriceConversion(ctx context.Context, baseCurrencyID,
 
 	// Check the status code
 	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("bad response from provider: %d", resp.StatusCode)
+		err = newProviderHTTPError(http.MethodGet, reqURL, resp)
 		return
 	}
 
 	// Try and decode the response
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		err = newDecodeError(err)
+	}
 	return
 }
 
@@ -361,6 +384,13 @@ func (p *PaprikaClient) GetMarketPrice(ctx context.Context, coinID string) (resp
 	response.LastRequest.Method = http.MethodGet
 	response.LastRequest.URL = reqURL
 
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			p.logResponseError(ctx, response.LastRequest, err)
+		}
+	}()
+
 	// Fire the request
 	var resp *http.Response
 	if resp, err = p.HTTPClient.Do(req); err != nil {
@@ -380,12 +410,80 @@ func (p *PaprikaClient) GetMarketPrice(ctx context.Context, coinID string) (resp
 
 	// Check the status code
 	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("bad response from provider: %d", resp.StatusCode)
+		err = newProviderHTTPError(http.MethodGet, reqURL, resp)
 		return
 	}
 
 	// Try and decode the response
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		err = newDecodeError(err)
+	}
+	return
+}
+
+// GetGlobal returns a response of the global market overview from Coin Paprika
+// (a cheap request that is used for health checks)
+//
+// See: https://api.coinpaprika.com/#operation/getGlobal
+func (p *PaprikaClient) GetGlobal(ctx context.Context) (response *GlobalResponse, err error) {
+
+	// Set the api url
+	reqURL := coinPaprikaBaseURL + "global"
+
+	// Start the request
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(
+		ctx, http.MethodGet, reqURL, nil,
+	); err != nil {
+		return
+	}
+
+	// Set the headers
+	req.Header.Set("Content-Type", "application/json")
+
+	// Change the header (user agent is in case they block default Go user agents)
+	req.Header.Set("User-Agent", p.UserAgent)
+
+	// Start the response
+	response = new(GlobalResponse)
+	response.LastRequest = new(lastRequest)
+	response.LastRequest.Method = http.MethodGet
+	response.LastRequest.URL = reqURL
+
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			p.logResponseError(ctx, response.LastRequest, err)
+		}
+	}()
+
+	// Fire the request
+	var resp *http.Response
+	if resp, err = p.HTTPClient.Do(req); err != nil {
+		if resp != nil {
+			response.LastRequest.StatusCode = resp.StatusCode
+		}
+		return
+	}
+
+	// Close the body
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Set the status
+	response.LastRequest.StatusCode = resp.StatusCode
+
+	// Check the status code
+	if resp.StatusCode != http.StatusOK {
+		err = newProviderHTTPError(http.MethodGet, reqURL, resp)
+		return
+	}
+
+	// Try and decode the response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		err = newDecodeError(err)
+	}
 	return
 }
 
@@ -454,6 +552,31 @@ type HistoricalTicker struct {
 	Volume24h int64   `json:"volume_24h"`
 }
 
+// historicalTickersURL will return the url for the historical tickers request
+// (the limit defaults to the max if it is not set or too large)
+func historicalTickersURL(coinID string, start, end time.Time, limit int,
+	quote tickerQuote, interval tickerInterval) string {
+
+	// Check for "max" limit (set default if not set)
+	if limit > maxHistoricalLimit {
+		limit = maxHistoricalLimit
+	} else if limit <= 0 {
+		limit = maxHistoricalLimit
+	}
+
+	// tickers/:coin_id/historical?start=
+	return fmt.Sprintf(
+		"%stickers/%s/historical?start=%d&end=%d&limit=%d&quote=%s&interval=%s",
+		coinPaprikaBaseURL,
+		coinID,
+		start.Unix(),
+		end.Unix(),
+		limit,
+		quote,
+		interval,
+	)
+}
+
 // GetHistoricalTickers will return the historical tickers given the range of time
 //
 // See: https://api.coinpaprika.com/#tag/Tickers/paths/~1tickers~1{coin_id}~1historical/get
@@ -477,25 +600,8 @@ func (p *PaprikaClient) GetHistoricalTickers(ctx context.Context, coinID string,
 		return
 	}
 
-	// Check for "max" limit (set default if not set)
-	if limit > maxHistoricalLimit {
-		limit = maxHistoricalLimit
-	} else if limit <= 0 {
-		limit = maxHistoricalLimit
-	}
-
 	// Set the api url
-	// tickers/:coin_id/historical?start=
-	reqURL := fmt.Sprintf(
-		"%stickers/%s/historical?start=%d&end=%d&limit=%d&quote=%s&interval=%s",
-		coinPaprikaBaseURL,
-		coinID,
-		start.Unix(),
-		end.Unix(),
-		limit,
-		quote,
-		interval,
-	)
+	reqURL := historicalTickersURL(coinID, start, end, limit, quote, interval)
 
 	// Start the request
 	var req *http.Request
@@ -517,6 +623,13 @@ func (p *PaprikaClient) GetHistoricalTickers(ctx context.Context, coinID string,
 	response.LastRequest.Method = http.MethodGet
 	response.LastRequest.URL = reqURL
 
+	// Log any failed request
+	defer func() {
+		if err != nil {
+			p.logResponseError(ctx, response.LastRequest, err)
+		}
+	}()
+
 	// Fire the request
 	var resp *http.Response
 	if resp, err = p.HTTPClient.Do(req); err != nil {
@@ -536,12 +649,14 @@ func (p *PaprikaClient) GetHistoricalTickers(ctx context.Context, coinID string,
 
 	// Check the status code
 	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("bad response from provider: %d", resp.StatusCode)
+		err = newProviderHTTPError(http.MethodGet, reqURL, resp)
 		return
 	}
 
 	// Try and decode the response
-	err = json.NewDecoder(resp.Body).Decode(&response.Results)
+	if err = json.NewDecoder(resp.Body).Decode(&response.Results); err != nil {
+		err = newDecodeError(err)
+	}
 	return
 }
 
@@ -3,6 +3,7 @@ package bsvrates
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -50,6 +51,21 @@ func (m *mockHTTPPaprika) Do(req *http.Request) (*http.Response, error) {
 		return resp, fmt.Errorf(`http timeout`)
 	}
 
+	// Invalid (rate limited)
+	if req.URL.String() == coinPaprikaBaseURL+"price-converter?base_currency_id="+USDCurrencyID+"&quote_currency_id="+CoinPaprikaQuoteID+"&amount=429.000000" {
+		resp.StatusCode = http.StatusTooManyRequests
+		resp.Header = http.Header{"Retry-After": []string{"120"}}
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(``)))
+		return resp, nil
+	}
+
+	// Invalid (malformed json)
+	if req.URL.String() == coinPaprikaBaseURL+"price-converter?base_currency_id="+USDCurrencyID+"&quote_currency_id="+CoinPaprikaQuoteID+"&amount=503.000000" {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{"price":`)))
+		return resp, nil
+	}
+
 	// Invalid (bad gateway)
 	if req.URL.String() == coinPaprikaBaseURL+"price-converter?base_currency_id="+USDCurrencyID+"&quote_currency_id="+CoinPaprikaQuoteID+"&amount=502.000000" {
 		resp.StatusCode = http.StatusBadGateway
@@ -80,6 +96,16 @@ func (m *mockHTTPPaprika) Do(req *http.Request) (*http.Response, error) {
 		return resp, fmt.Errorf(`http bad gateway`)
 	}
 
+	//
+	// Get Global
+	//
+
+	// Valid
+	if req.URL.String() == coinPaprikaBaseURL+"global" {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{"market_cap_usd":1105484123450,"volume_24h_usd":54826571234,"bitcoin_dominance_percentage":55.52,"cryptocurrencies_number":9102,"market_cap_ath_value":2986280364287,"market_cap_ath_date":"2021-11-10T16:45:00Z","volume_24h_ath_value":33730718750765,"volume_24h_ath_date":"2022-11-10T00:00:00Z","market_cap_change_24h":-0.26,"volume_24h_change_24h":7.61,"last_updated":1688478363}`)))
+	}
+
 	//
 	// Get Historical Tickers
 	//
@@ -113,6 +139,17 @@ func (m *mockHTTPPaprika) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// mockHTTPPaprikaDown for mocking requests (every request is unavailable)
+type mockHTTPPaprikaDown struct{}
+
+// Do is a mock http request
+func (m *mockHTTPPaprikaDown) Do(_ *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusServiceUnavailable
+	resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(``)))
+	return resp, nil
+}
+
 // newMockPaprikaClient returns a client for mocking (using a custom HTTP interface)
 func newMockPaprikaClient(httpClient HTTPInterface) ClientInterface {
 	client := NewClient(nil, httpClient)
@@ -183,6 +220,7 @@ func TestPaprikaClient_GetBaseAmountAndCurrencyID(t *testing.T) {
 		{"mxn", "mxn", 0.01, MXNCurrencyID, 0.01},
 		{"new", "new", 0.01, NEWCurrencyID, 0.01},
 		{"nok", "nok", 0.01, NOKCurrencyID, 0.01},
+		{"nzd", "nzd", 0.01, NEWCurrencyID, 0.01},
 		{"pln", "pln", 0.01, PLNCurrencyID, 0.01},
 		{"rub", "rub", 0.01, RUBCurrencyID, 0.01},
 		{"sek", "sek", 0.01, SEKCurrencyID, 0.01},
@@ -287,6 +325,45 @@ func TestPaprikaClient_GetPriceConversion(t *testing.T) {
 	})
 }
 
+// TestPaprikaClient_GetPriceConversion_Errors will test the typed errors from GetPriceConversion()
+func TestPaprikaClient_GetPriceConversion_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := newMockPaprikaClient(&mockHTTPPaprika{})
+
+	t.Run("rate limited", func(t *testing.T) {
+		response, err := client.CoinPaprika().GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 429)
+		assert.Error(t, err)
+		assert.NotNil(t, response)
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.False(t, errors.Is(err, ErrDecode))
+
+		var httpErr *ProviderHTTPError
+		if assert.True(t, errors.As(err, &httpErr)) {
+			assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+			assert.Equal(t, 2*time.Minute, httpErr.RetryAfter)
+			assert.Equal(t, http.MethodGet, httpErr.Method)
+			assert.Contains(t, httpErr.URL, "price-converter")
+		}
+	})
+
+	t.Run("bad gateway", func(t *testing.T) {
+		_, err := client.CoinPaprika().GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 502)
+		assert.EqualError(t, err, "bad response from provider: 502")
+		assert.False(t, errors.Is(err, ErrRateLimited))
+
+		var httpErr *ProviderHTTPError
+		assert.True(t, errors.As(err, &httpErr))
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		_, err := client.CoinPaprika().GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 503)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrDecode))
+		assert.False(t, errors.Is(err, ErrRateLimited))
+	})
+}
+
 // TestPaprikaClient_GetMarketPrice will test the method GetMarketPrice()
 func TestPaprikaClient_GetMarketPrice(t *testing.T) {
 	// t.Parallel()
@@ -347,6 +424,30 @@ func TestPaprikaClient_GetMarketPrice(t *testing.T) {
 	})
 }
 
+// TestPaprikaClient_GetGlobal will test the method GetGlobal()
+func TestPaprikaClient_GetGlobal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid response", func(t *testing.T) {
+		client := newMockPaprikaClient(&mockHTTPPaprika{})
+		output, err := client.CoinPaprika().GetGlobal(context.Background())
+		assert.NoError(t, err)
+		assert.NotNil(t, output)
+		assert.Equal(t, int64(1105484123450), output.MarketCapUSD)
+		assert.Equal(t, 9102, output.CryptocurrenciesNumber)
+		assert.Equal(t, int64(1688478363), output.LastUpdated)
+		assert.Equal(t, http.StatusOK, output.LastRequest.StatusCode)
+	})
+
+	t.Run("bad response", func(t *testing.T) {
+		client := newMockPaprikaClient(&mockHTTPPaprikaDown{})
+		output, err := client.CoinPaprika().GetGlobal(context.Background())
+		assert.EqualError(t, err, "bad response from provider: 503")
+		assert.NotNil(t, output)
+		assert.Equal(t, http.StatusServiceUnavailable, output.LastRequest.StatusCode)
+	})
+}
+
 // TestPaprikaClient_IsAcceptedCurrency will test the method IsAcceptedCurrency()
 func TestPaprikaClient_IsAcceptedCurrency(t *testing.T) {
 	// t.Parallel()
@@ -568,7 +669,7 @@ func TestPaprikaClient_GetHistoricalTickers(t *testing.T) {
 	})
 
 	t.Run("invalid response", func(t *testing.T) {
-		client = newMockClient(&mockWOCValid{}, &mockPaprikaFailed{})
+		client = newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		resp, rateErr := client.CoinPaprika().GetHistoricalTickers(
@@ -10,6 +10,9 @@ const (
 	// defaultUserAgent is the default user agent for all requests
 	defaultUserAgent string = "go-bsvrates: " + version
 
+	// CoinGeckoCoinID is the id for CoinGecko (BSV)
+	CoinGeckoCoinID = "bitcoin-cash-sv"
+
 	// CoinPaprikaQuoteID is the id for CoinPaprika (BSV)
 	CoinPaprikaQuoteID = "bsv-bitcoin-sv"
 )
@@ -29,12 +32,17 @@ const (
 
 	ProviderWhatsOnChain // 1
 	ProviderCoinPaprika  // 2
-	providerLast         // 3
+	ProviderCoinGecko    // 3
+	ProviderBitfinex     // 4
+	providerLast         // 5
 )
 
+// ProviderCustom is the Provider reported for any custom RateProvider (not built-in)
+const ProviderCustom Provider = 255
+
 // IsValid tests if the provider is valid or not
 func (p Provider) IsValid() bool {
-	return p >= ProviderWhatsOnChain && p < providerLast
+	return (p >= ProviderWhatsOnChain && p < providerLast) || p == ProviderCustom
 }
 
 // Name will return the display name for the given provider
@@ -44,6 +52,12 @@ func (p Provider) Name() string {
 		return "WhatsOnChain"
 	case ProviderCoinPaprika:
 		return "CoinPaprika"
+	case ProviderCoinGecko:
+		return "CoinGecko"
+	case ProviderBitfinex:
+		return "Bitfinex"
+	case ProviderCustom:
+		return "Custom"
 	case providerLast:
 		return ""
 	default:
@@ -66,29 +80,71 @@ const (
 	CurrencyDollars          = 1
 	CurrencyBitcoin          = 2
 
-	currencyLast = iota
+	// Fiat currencies (ISO 4217) supported by at least one provider
+	CurrencyAustralianDollar Currency = 3
+	CurrencyBrazilianReal    Currency = 4
+	CurrencyCanadianDollar   Currency = 5
+	CurrencySwissFranc       Currency = 6
+	CurrencyChineseYuan      Currency = 7
+	CurrencyEuro             Currency = 8
+	CurrencyBritishPound     Currency = 9
+	CurrencyJapaneseYen      Currency = 10
+	CurrencySouthKoreanWon   Currency = 11
+	CurrencyMexicanPeso      Currency = 12
+	CurrencyNewZealandDollar Currency = 13
+	CurrencyNorwegianKrone   Currency = 14
+	CurrencyPolishZloty      Currency = 15
+	CurrencyRussianRuble     Currency = 16
+	CurrencySwedishKrona     Currency = 17
+	CurrencyTurkishLira      Currency = 18
+	CurrencyTaiwanDollar     Currency = 19
+	CurrencySouthAfricanRand Currency = 20
+	currencyLast                      = iota
 )
 
+// currencyNames is the display name (ISO 4217 code) for each currency
+var currencyNames = map[Currency]string{
+	CurrencyDollars:          usd,
+	CurrencyBitcoin:          "bsv",
+	CurrencyAustralianDollar: "aud",
+	CurrencyBrazilianReal:    "brl",
+	CurrencyCanadianDollar:   "cad",
+	CurrencySwissFranc:       "chf",
+	CurrencyChineseYuan:      "cny",
+	CurrencyEuro:             "eur",
+	CurrencyBritishPound:     "gbp",
+	CurrencyJapaneseYen:      "jpy",
+	CurrencySouthKoreanWon:   "krw",
+	CurrencyMexicanPeso:      "mxn",
+	CurrencyNewZealandDollar: "nzd",
+	CurrencyNorwegianKrone:   "nok",
+	CurrencyPolishZloty:      "pln",
+	CurrencyRussianRuble:     "rub",
+	CurrencySwedishKrona:     "sek",
+	CurrencyTurkishLira:      "try",
+	CurrencyTaiwanDollar:     "twd",
+	CurrencySouthAfricanRand: "zar",
+}
+
 // IsValid tests if the provider is valid or not
 func (c Currency) IsValid() bool {
 	return c >= CurrencyDollars && c < currencyLast
 }
 
-// IsAccepted tests if the currency is accepted by all providers
+// IsFiat tests if the currency is a fiat currency (not BSV)
+func (c Currency) IsFiat() bool {
+	return c.IsValid() && c != CurrencyBitcoin
+}
+
+// IsAccepted tests if the currency can be quoted by at least one provider
+// (use SupportsCurrency() on each RateProvider for the per-provider capability)
 func (c Currency) IsAccepted() bool {
-	return c == CurrencyDollars
+	return c.IsFiat()
 }
 
 // Name will return the display name for the given currency
 func (c Currency) Name() string {
-	switch c {
-	case CurrencyDollars:
-		return usd
-	case CurrencyBitcoin:
-		return "bsv"
-	default:
-		return ""
-	}
+	return currencyNames[c]
 }
 
 // CurrencyToName helper function to convert the currency value to it's associated name
@@ -98,12 +154,11 @@ func CurrencyToName(currency Currency) string {
 
 // CurrencyFromName helper function to convert the name into it's Currency type
 func CurrencyFromName(name string) Currency {
-	switch strings.ToLower(name) {
-	case usd:
-		return CurrencyDollars
-	case "bsv":
-		return CurrencyBitcoin
-	default:
-		return CurrencyDollars
+	name = strings.ToLower(name)
+	for currency, currencyName := range currencyNames {
+		if currencyName == name {
+			return currency
+		}
 	}
+	return CurrencyDollars
 }
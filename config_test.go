@@ -18,10 +18,15 @@ func TestProvider_IsValid(t *testing.T) {
 		{"provider 0", 0, false},
 		{"provider 1", 1, true},
 		{"provider 2", 2, true},
-		{"provider 3", 3, false},
+		{"provider 3", 3, true},
+		{"provider 4", 4, true},
+		{"provider 5", 5, false},
 		{"ProviderWhatsOnChain", ProviderWhatsOnChain, true},
 		{"ProviderCoinPaprika", ProviderCoinPaprika, true},
+		{"ProviderCoinGecko", ProviderCoinGecko, true},
+		{"ProviderBitfinex", ProviderBitfinex, true},
 		{"providerLast", providerLast, false},
+		{"ProviderCustom", ProviderCustom, true},
 	}
 	for _, test := range tests {
 		t.Run(test.testCase, func(t *testing.T) {
@@ -43,10 +48,15 @@ func TestProvider_Name(t *testing.T) {
 		{"provider 0", 0, ""},
 		{"provider 1", 1, "WhatsOnChain"},
 		{"provider 2", 2, "CoinPaprika"},
-		{"provider 4", 3, ""},
+		{"provider 3", 3, "CoinGecko"},
+		{"provider 4", 4, "Bitfinex"},
+		{"provider 5", 5, ""},
 		{"ProviderWhatsOnChain", ProviderWhatsOnChain, "WhatsOnChain"},
 		{"ProviderCoinPaprika", ProviderCoinPaprika, "CoinPaprika"},
+		{"ProviderCoinGecko", ProviderCoinGecko, "CoinGecko"},
+		{"ProviderBitfinex", ProviderBitfinex, "Bitfinex"},
 		{"providerLast", providerLast, ""},
+		{"ProviderCustom", ProviderCustom, "Custom"},
 	}
 	for _, test := range tests {
 		t.Run(test.testCase, func(t *testing.T) {
@@ -68,10 +78,15 @@ func TestProviderToName(t *testing.T) {
 		{"provider 0", 0, ""},
 		{"provider 1", 1, "WhatsOnChain"},
 		{"provider 2", 2, "CoinPaprika"},
-		{"provider 4", 3, ""},
+		{"provider 3", 3, "CoinGecko"},
+		{"provider 4", 4, "Bitfinex"},
+		{"provider 5", 5, ""},
 		{"ProviderWhatsOnChain", ProviderWhatsOnChain, "WhatsOnChain"},
 		{"ProviderCoinPaprika", ProviderCoinPaprika, "CoinPaprika"},
+		{"ProviderCoinGecko", ProviderCoinGecko, "CoinGecko"},
+		{"ProviderBitfinex", ProviderBitfinex, "Bitfinex"},
 		{"providerLast", providerLast, ""},
+		{"ProviderCustom", ProviderCustom, "Custom"},
 	}
 	for _, test := range tests {
 		t.Run(test.testCase, func(t *testing.T) {
@@ -94,9 +109,12 @@ func TestCurrency_IsValid(t *testing.T) {
 		{"currency 0", 0, false},
 		{"currency 1", 1, true},
 		{"currency 2", 2, true},
-		{"currency 3", 3, false},
+		{"currency 3", 3, true},
+		{"currency 21", 21, false},
 		{"CurrencyDollars", CurrencyDollars, true},
 		{"CurrencyBitcoin", CurrencyBitcoin, true},
+		{"CurrencyEuro", CurrencyEuro, true},
+		{"CurrencySouthAfricanRand", CurrencySouthAfricanRand, true},
 		{"currencyLast", currencyLast, false},
 	}
 	for _, test := range tests {
@@ -120,9 +138,12 @@ func TestCurrency_Name(t *testing.T) {
 		{"currency 0", 0, ""},
 		{"currency 1", 1, usd},
 		{"currency 2", 2, "bsv"},
-		{"currency 3", 3, ""},
+		{"currency 3", 3, "aud"},
+		{"currency 21", 21, ""},
 		{"CurrencyDollars", CurrencyDollars, usd},
 		{"CurrencyBitcoin", CurrencyBitcoin, "bsv"},
+		{"CurrencyEuro", CurrencyEuro, "eur"},
+		{"CurrencyNewZealandDollar", CurrencyNewZealandDollar, "nzd"},
 		{"currencyLast", currencyLast, ""},
 	}
 	for _, test := range tests {
@@ -146,9 +167,12 @@ func TestCurrencyToName(t *testing.T) {
 		{"currency 0", 0, ""},
 		{"currency 1", 1, usd},
 		{"currency 2", 2, "bsv"},
-		{"currency 3", 3, ""},
+		{"currency 3", 3, "aud"},
+		{"currency 21", 21, ""},
 		{"CurrencyDollars", CurrencyDollars, usd},
 		{"CurrencyBitcoin", CurrencyBitcoin, "bsv"},
+		{"CurrencyEuro", CurrencyEuro, "eur"},
+		{"CurrencyNewZealandDollar", CurrencyNewZealandDollar, "nzd"},
 		{"currencyLast", currencyLast, ""},
 	}
 	for _, test := range tests {
@@ -171,6 +195,9 @@ func TestCurrencyFromName(t *testing.T) {
 		{"", "", CurrencyDollars},
 		{usd, usd, CurrencyDollars},
 		{"bsv", "bsv", CurrencyBitcoin},
+		{"eur", "eur", CurrencyEuro},
+		{"EUR", "EUR", CurrencyEuro},
+		{"jpy", "jpy", CurrencyJapaneseYen},
 		{"bogus", "bogus", CurrencyDollars},
 	}
 	for _, test := range tests {
@@ -193,9 +220,11 @@ func TestCurrency_IsAccepted(t *testing.T) {
 		{"currency 0", 0, false},
 		{"currency 1", 1, true},
 		{"currency 2", 2, false},
-		{"currency 3", 3, false},
+		{"currency 3", 3, true},
+		{"currency 21", 21, false},
 		{"CurrencyDollars", CurrencyDollars, true},
 		{"CurrencyBitcoin", CurrencyBitcoin, false},
+		{"CurrencyEuro", CurrencyEuro, true},
 		{"currencyLast", currencyLast, false},
 	}
 	for _, test := range tests {
@@ -205,3 +234,26 @@ func TestCurrency_IsAccepted(t *testing.T) {
 		})
 	}
 }
+
+// TestCurrency_IsFiat will test the method IsFiat()
+func TestCurrency_IsFiat(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		testCase     string
+		currency     Currency
+		expectedFiat bool
+	}{
+		{"currency 0", 0, false},
+		{"CurrencyDollars", CurrencyDollars, true},
+		{"CurrencyBitcoin", CurrencyBitcoin, false},
+		{"CurrencyEuro", CurrencyEuro, true},
+		{"CurrencyJapaneseYen", CurrencyJapaneseYen, true},
+		{"currencyLast", currencyLast, false},
+	}
+	for _, test := range tests {
+		t.Run(test.testCase, func(t *testing.T) {
+			assert.Equal(t, test.expectedFiat, test.currency.IsFiat())
+		})
+	}
+}
@@ -3,50 +3,234 @@ package bsvrates
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/mrz1836/go-whatsonchain"
+	"github.com/shopspring/decimal"
 )
 
+// ConversionResult is the result of converting an amount of a currency into satoshis
+type ConversionResult struct {
+	Amount       float64        `json:"amount"`        // Amount of the currency that was converted
+	Cache        CacheStatus    `json:"cache"`         // Cache status of the rate used
+	CrossRate    float64        `json:"cross_rate"`    // Currency->USD rate used (zero if the provider quoted the currency)
+	Currency     Currency       `json:"currency"`      // Currency of the amount
+	Errors       ProviderErrors `json:"errors"`        // Providers that failed before the conversion was found
+	Provider     Provider       `json:"provider"`      // Provider used (ProviderCustom if not built-in)
+	ProviderName string         `json:"provider_name"` // Display name of the provider used
+	Rate         float64        `json:"rate"`          // Effective BSV->Currency rate used for the conversion
+	Satoshis     int64          `json:"satoshis"`      // Satoshis for the given amount
+	Side         QuoteSide      `json:"side"`          // Side of the quote used (see GetSideConversion)
+	SpreadBps    float64        `json:"spread_bps"`    // Spread applied to the rate in basis points (see GetSideConversion)
+}
+
 // GetConversion will get the satoshi amount for the given currency + amount provided.
-// The first provider that succeeds is the conversion that is returned
+// The first provider that succeeds is the conversion that is returned.
+//
+// If ClientOptions.HedgeDelay is set, the next provider is also requested when a provider has
+// not answered within the delay (the first conversion wins and the slower requests are cancelled).
+//
+// If the consensus rules are enabled (MinimumQuorum or MaxDeviationPercent), the median of
+// the agreeing providers is used instead (see GetAggregatedConversion)
 func (c *Client) GetConversion(ctx context.Context, currency Currency, amount float64) (satoshis int64, providerUsed Provider, err error) {
+	var result *ConversionResult
+	if result, providerUsed, err = c.getConversion(ctx, currency, amount); result != nil {
+		satoshis = result.Satoshis
+	}
+	return
+}
 
-	// Check if currency is accepted across all providers
+// GetConversionDetails will get the satoshi amount for the given currency + amount provided,
+// including the provider, currency and rate that were actually used.
+//
+// Providers that cannot quote the currency (but can quote USD) are cross-converted
+// using the FiatRateSource (see ClientOptions.FiatRates). If the fiat rate fails, those
+// providers are skipped and the failure is reported under the name of the fiat rate source.
+//
+// If the cache is enabled (ClientOptions.CacheTTL), the conversion is calculated
+// from the (cached) provider rate instead of a conversion request
+func (c *Client) GetConversionDetails(ctx context.Context, currency Currency,
+	amount float64) (result *ConversionResult, err error) {
+	result, _, err = c.getConversion(ctx, currency, amount)
+	return
+}
+
+// getConversion will get the conversion from the first provider that succeeds
+// (the last provider attempted is always returned)
+func (c *Client) getConversion(ctx context.Context, currency Currency,
+	amount float64) (result *ConversionResult, providerUsed Provider, err error) {
+
+	// Trace the lookup
+	ctx, span := c.tracer.Start(ctx, spanConversion,
+		Attribute{Key: "currency", Value: currency.Name()},
+		Attribute{Key: "amount", Value: amount},
+	)
+	defer func() {
+		endLookup(span, providerUsed, err)
+	}()
+
+	// Check if currency is accepted by any provider
 	if !currency.IsAccepted() {
-		err = fmt.Errorf("currency [%s] is not accepted by all providers at this time", currency.Name())
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not accepted by all providers at this time", currency.Name())
 		return
 	}
 
-	// Loop providers and get a conversion value
-	for _, provider := range c.Providers() {
-		providerUsed = provider
-		switch provider {
-		case ProviderCoinPaprika:
-			var response *PriceConversionResponse
-			if response, err = c.CoinPaprika().GetPriceConversion(
-				ctx, USDCurrencyID, CoinPaprikaQuoteID, amount,
-			); err == nil && response != nil {
-				satoshis, err = response.GetSatoshi()
+	// Consensus rules are enabled (use the median of the agreeing providers)
+	if c.options.useConsensus() {
+		var aggregated *AggregatedConversion
+		if aggregated, err = c.GetAggregatedConversion(ctx, currency, amount, AggregationMedian); err == nil {
+			providerUsed = aggregated.Contributors[0].Provider
+			result = &ConversionResult{
+				Amount:       amount,
+				Cache:        aggregated.cacheStatus(),
+				Currency:     currency,
+				Errors:       aggregated.Errors,
+				Provider:     providerUsed,
+				ProviderName: aggregated.Contributors[0].Name,
+				Rate:         aggregated.Rate,
+				Satoshis:     aggregated.Satoshis,
 			}
-		case ProviderWhatsOnChain:
-			var response *whatsonchain.ExchangeRate
-			if response, err = c.WhatsOnChain().GetExchangeRate(ctx); err == nil && response != nil {
-				satoshis, err = ConvertPriceToSatoshis(response.Rate, amount)
+		}
+		return
+	}
+
+	// Only use the providers that can quote the currency (or USD, if it can be cross-converted)
+	var providers []RateProvider
+	for _, provider := range c.RateProviders() {
+		if provider.SupportsCurrency(currency) ||
+			(c.FiatRates() != nil && provider.SupportsCurrency(CurrencyDollars)) {
+			providers = append(providers, provider)
+		}
+	}
+	if providers = c.orderProviders(providers); len(providers) == 0 {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not supported by any provider", currency.Name())
+		return
+	}
+
+	// Get the cross rate once if a provider can only quote USD (if it fails, the failure is
+	// recorded for the fiat rate source and the USD only providers are skipped)
+	var crossRate float64
+	var errs ProviderErrors
+	if !supportsCurrency(providers, currency) {
+		start := time.Now()
+		if crossRate, err = c.FiatRates().GetFiatRate(ctx, currency, CurrencyDollars); err == nil && crossRate <= 0 {
+			err = newKindError(ErrInvalidRate, "no fiat rate returned from [%s] to [usd]", currency.Name())
+		}
+		if err != nil {
+			fiatErr := newFiatRatesError(c.FiatRates(), err, time.Since(start))
+			errs, err = append(errs, fiatErr), nil
+			var quoted []RateProvider
+			for _, provider := range providers {
+				if provider.SupportsCurrency(currency) {
+					quoted = append(quoted, provider)
+				}
 			}
-		case providerLast:
-			err = fmt.Errorf("provider unknown")
-			return
+			providers = quoted
+			c.logFailure(ctx, "conversion", fiatErr, currency, len(providers) > 0)
 		}
+	}
+	if len(providers) == 0 {
+		c.logAllFailed(ctx, "conversion", currency, errs)
+		err = errs
+		return
+	}
+
+	// Request the providers in order (every failure is recorded). If HedgeDelay is set,
+	// the next provider is also requested while a slow provider has not answered
+	attempts := make([]providerAttempt, len(providers))
+	winner, failed := hedge(ctx, c.options.HedgeDelay, len(providers), func(ctx context.Context, index int) error {
+		provider, attempt := providers[index], &attempts[index]
+		start := time.Now()
+		defer func() {
+			attempt.latency = time.Since(start)
+		}()
 
-		// todo: log the error for sanity in case the user want's to see the failure?
+		// Cross-convert the amount into USD if the provider cannot quote the currency
+		conversionCurrency, conversionAmount := currency, amount
+		if !provider.SupportsCurrency(currency) {
+			attempt.crossRate, conversionCurrency = crossRate, CurrencyDollars
+			conversionAmount, _ = decimal.NewFromFloat(amount).Mul(decimal.NewFromFloat(crossRate)).Float64()
+		}
 
-		// Did we get a satoshi value? Otherwise, keep looping
-		if satoshis > 0 {
-			return
+		// Get the conversion
+		c.logAttempt(ctx, "conversion", provider, conversionCurrency, index+1)
+		attemptCtx, attemptSpan := c.startAttempt(ctx, provider, conversionCurrency, index)
+		attempt.satoshis, attempt.status, attempt.err = c.getProviderConversion(
+			attemptCtx, provider, conversionCurrency, conversionAmount,
+		)
+		if attempt.err == nil && attempt.satoshis <= 0 {
+			attempt.err = fmt.Errorf("no conversion returned from %s", provider.Name())
 		}
+		endAttempt(attemptSpan, attempt.status, attempt.err)
+		return attempt.err
+	})
+
+	// Record the failures (in the order they failed)
+	for _, index := range failed {
+		attempt := &attempts[index]
+		providerUsed = providerType(providers[index])
+		errs = append(errs, c.providerFailed(
+			ctx, "conversion", providers[index], currency, attempt.err, attempt.latency, index < len(providers)-1,
+		))
 	}
 
+	// All the providers failed
+	if winner < 0 {
+		c.logAllFailed(ctx, "conversion", currency, errs)
+		err = errs
+		return
+	}
+
+	// Found a conversion
+	provider, attempt := providers[winner], &attempts[winner]
+	providerUsed = providerType(provider)
+	c.logResult(ctx, "conversion", provider, currency, len(errs), attempt.latency)
+	result = &ConversionResult{
+		Amount:       amount,
+		Cache:        attempt.status,
+		CrossRate:    attempt.crossRate,
+		Currency:     currency,
+		Errors:       errs,
+		Provider:     providerUsed,
+		ProviderName: provider.Name(),
+		Rate:         effectiveRate(amount, attempt.satoshis),
+		Satoshis:     attempt.satoshis,
+	}
 	return
 }
 
-// todo: create a new method to get all three and then average the results
+// supportsCurrency will return true if every provider can quote the currency
+func supportsCurrency(providers []RateProvider, currency Currency) bool {
+	for _, provider := range providers {
+		if !provider.SupportsCurrency(currency) {
+			return false
+		}
+	}
+	return true
+}
+
+// getProviderConversion will get the conversion from the provider
+// (if the cache or MaxRateAge is enabled, the conversion uses the (cached) provider rate)
+func (c *Client) getProviderConversion(ctx context.Context, provider RateProvider, currency Currency,
+	amount float64) (satoshis int64, status CacheStatus, err error) {
+
+	// No cache or maximum age
+	if c.cache == nil && c.options.MaxRateAge <= 0 {
+		satoshis, err = c.requestConversion(ctx, provider, currency, amount)
+		return
+	}
+
+	// Convert using the (cached) rate
+	var rate float64
+	if rate, status, err = c.getProviderRate(ctx, provider, currency); err == nil {
+		satoshis, err = ConvertPriceToSatoshis(rate, amount)
+	}
+	return
+}
+
+// effectiveRate will return the BSV->Currency rate implied by converting the amount into satoshis
+func effectiveRate(amount float64, satoshis int64) float64 {
+	rate, _ := decimal.NewFromFloat(amount).Mul(
+		decimal.NewFromInt(SatoshisPerBitcoin),
+	).Div(decimal.NewFromInt(satoshis)).Round(8).Float64()
+	return rate
+}
@@ -2,6 +2,8 @@ package bsvrates
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +14,7 @@ func TestClient_GetConversion(t *testing.T) {
 	t.Parallel()
 
 	t.Run("valid get conversion - default", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -23,7 +25,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("valid get conversion - whats on chain", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -34,7 +36,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("valid get conversion - coin paprika", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika)
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika)
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -45,7 +47,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("valid get conversion - custom provider list", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika, ProviderWhatsOnChain)
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika, ProviderWhatsOnChain)
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -56,7 +58,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("non accepted currency", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		_, _, rateErr := client.GetConversion(context.Background(), 123, 1)
@@ -64,7 +66,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("failed conversion - default", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -75,7 +77,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("failed conversion - whats on chain", func(t *testing.T) {
-		client := newMockClient(&mockWOCFailed{}, &mockPaprikaValid{})
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaValid{})
 		assert.NotNil(t, client)
 
 		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -86,7 +88,7 @@ func TestClient_GetConversion(t *testing.T) {
 	})
 
 	t.Run("failed conversion - all providers", func(t *testing.T) {
-		client := newMockClient(&mockWOCFailed{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		satoshis, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
@@ -94,3 +96,121 @@ func TestClient_GetConversion(t *testing.T) {
 		assert.Equal(t, int64(0), satoshis)
 	})
 }
+
+// mockFiatRatesFailed for mocking fiat rate requests
+type mockFiatRatesFailed struct{}
+
+// GetFiatRate is a mock response
+func (m *mockFiatRatesFailed) GetFiatRate(_ context.Context, _, _ Currency) (float64, error) {
+	return 0, fmt.Errorf("some error occurred")
+}
+
+// TestClient_GetConversionDetails will test the method GetConversionDetails()
+func TestClient_GetConversionDetails(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid conversion - usd", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(633157), result.Satoshis)
+		assert.Equal(t, Currency(CurrencyDollars), result.Currency)
+		assert.Equal(t, ProviderCoinPaprika, result.Provider)
+		assert.Equal(t, "CoinPaprika", result.ProviderName)
+		assert.Equal(t, 157.9387103, result.Rate)
+		assert.Equal(t, float64(0), result.CrossRate)
+	})
+
+	t.Run("valid conversion - euro (quoted by coin paprika)", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyEuro, 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(633157), result.Satoshis)
+		assert.Equal(t, CurrencyEuro, result.Currency)
+		assert.Equal(t, ProviderCoinPaprika, result.Provider)
+		assert.Equal(t, float64(0), result.CrossRate)
+	})
+
+	t.Run("valid conversion - euro (cross-converted for whats on chain)", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyEuro, 10)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(6917804), result.Satoshis)
+		assert.Equal(t, CurrencyEuro, result.Currency)
+		assert.Equal(t, ProviderWhatsOnChain, result.Provider)
+		assert.Equal(t, 1.1, result.CrossRate)
+		assert.Equal(t, 144.55454361, result.Rate)
+	})
+
+	t.Run("cross-conversion fails", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		client.SetFiatRates(&mockFiatRatesFailed{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyEuro, 10)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		// The failure is reported for the fiat rate source (not WhatsOnChain)
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) && assert.Equal(t, 1, len(errs)) {
+			assert.Equal(t, defaultFiatRatesName, errs[0].Name)
+			assert.Equal(t, ProviderCustom, errs[0].Provider)
+		}
+	})
+
+	t.Run("cross-conversion fails - provider quoting the currency is used", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain, ProviderCoinPaprika)
+		client.SetFiatRates(&mockFiatRatesFailed{})
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyEuro, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderCoinPaprika, result.Provider)
+		assert.Equal(t, int64(633157), result.Satoshis)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, defaultFiatRatesName, result.Errors[0].Name)
+		}
+	})
+
+	t.Run("cross-conversion fails - default fiat rate source", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{}, ProviderWhatsOnChain)
+
+		_, err := client.GetConversionDetails(context.Background(), CurrencyEuro, 1)
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) && assert.Equal(t, 1, len(errs)) {
+			assert.Equal(t, "CoinPaprika fiat rates", errs[0].Name)
+			assert.Equal(t, ProviderCoinPaprika, errs[0].Provider)
+		}
+	})
+
+	t.Run("currency not supported by any provider", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{
+			&mockRateProvider{name: "in-house", currencies: []Currency{CurrencyEuro}, satoshis: 100},
+		}
+		client := NewClient(options, nil)
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyBritishPound, 10)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("non accepted currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyBitcoin, 1)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -37,9 +37,9 @@ func ConvertPriceToSatoshis(currentRate float64, amount float64) (int64, error)
 
 	// Cannot use 0 (division by zero?!)
 	if amount == 0 {
-		return 0, fmt.Errorf("an amount must be set")
+		return 0, newKindError(ErrInvalidAmount, "an amount must be set")
 	} else if currentRate <= 0 {
-		return 0, fmt.Errorf("current rate must be a positive value")
+		return 0, newKindError(ErrInvalidRate, "current rate must be a positive value")
 	}
 
 	// Do conversion to satoshis (percentage) using decimal package to avoid float issues
@@ -86,7 +86,7 @@ func TransformCurrencyToInt(decimalValue float64, currency Currency) (int64, err
 	} else if currency == CurrencyBitcoin {
 		return ConvertFloatToIntBSV(decimalValue), nil
 	}
-	return 0, fmt.Errorf("currency %s cannot be transformed", currency.Name())
+	return 0, newKindError(ErrUnsupportedCurrency, "currency %s cannot be transformed", currency.Name())
 }
 
 // TransformIntToCurrency will take the int and return a float value.
@@ -97,7 +97,7 @@ func TransformIntToCurrency(intValue int, currency Currency) (string, error) {
 	} else if currency == CurrencyBitcoin {
 		return fmt.Sprintf("%8.8f", ConvertSatsToBSV(intValue)), nil
 	}
-	return "", fmt.Errorf("currency %s cannot be transformed", currency.Name())
+	return "", newKindError(ErrUnsupportedCurrency, "currency %s cannot be transformed", currency.Name())
 }
 
 // ConvertFloatToIntBSV converts the BSV float value to the satoshis int value
@@ -0,0 +1,252 @@
+package bsvrates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors returned by the package (use errors.Is to check for them)
+var (
+	// ErrCircuitOpen is returned when a provider is skipped because its circuit breaker is open
+	ErrCircuitOpen = errors.New("provider circuit breaker is open")
+
+	// ErrDecode is returned when a provider response cannot be decoded (or is malformed)
+	ErrDecode = errors.New("failed to decode the provider response")
+
+	// ErrInvalidAmount is returned when an amount to convert is not set
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrInvalidRate is returned when a rate used for a conversion is not a positive value
+	ErrInvalidRate = errors.New("invalid rate")
+
+	// ErrRateLimited is returned when a provider responds with 429 Too Many Requests
+	ErrRateLimited = errors.New("rate limited by the provider")
+
+	// ErrStaleQuote is returned when a quote is older than ClientOptions.MaxRateAge
+	ErrStaleQuote = errors.New("quote is older than the maximum rate age")
+
+	// ErrUnsupportedCurrency is returned when a currency is not supported (by any provider)
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+)
+
+// ProviderHTTPError is returned when a provider responds with a non-200 status
+// (a 429 also matches ErrRateLimited). Custom providers can return it with RetryAfter
+// set to have the client back off from the provider
+type ProviderHTTPError struct {
+	Method     string        `json:"method"`      // Method of the request
+	RetryAfter time.Duration `json:"retry_after"` // How long to wait before retrying (from the Retry-After header)
+	StatusCode int           `json:"status_code"` // HTTP status of the response
+	URL        string        `json:"url"`         // URL of the request
+}
+
+// newProviderHTTPError will return the ProviderHTTPError for the response
+func newProviderHTTPError(method, reqURL string, resp *http.Response) *ProviderHTTPError {
+	return &ProviderHTTPError{
+		Method:     method,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		StatusCode: resp.StatusCode,
+		URL:        reqURL,
+	}
+}
+
+// parseRetryAfter will parse a Retry-After header (delay in seconds or an HTTP date)
+// into a duration (zero if missing, invalid or in the past)
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value = strings.TrimSpace(value); len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// errorRetryAfter will return how long the provider asked to wait before retrying (zero if unknown)
+func errorRetryAfter(err error) time.Duration {
+	var httpErr *ProviderHTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.RetryAfter
+	}
+	return 0
+}
+
+// Error will return the error message
+func (e *ProviderHTTPError) Error() string {
+	return fmt.Sprintf("bad response from provider: %d", e.StatusCode)
+}
+
+// Is will return true for ErrRateLimited if the status is 429 (for errors.Is)
+func (e *ProviderHTTPError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// kindError is an error with its own message that matches a sentinel error (for errors.Is)
+type kindError struct {
+	cause   error  // Underlying error (if any)
+	kind    error  // Sentinel error that it matches
+	message string // Error message
+}
+
+// newKindError will return an error with the message that matches the sentinel error
+func newKindError(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+// newDecodeError will return an ErrDecode error for the decoding error
+func newDecodeError(err error) error {
+	return &kindError{cause: err, kind: ErrDecode, message: ErrDecode.Error() + ": " + err.Error()}
+}
+
+// Error will return the error message
+func (e *kindError) Error() string {
+	return e.message
+}
+
+// Is will return true if the target is the sentinel error (for errors.Is)
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// Unwrap will return the underlying error (for errors.As)
+func (e *kindError) Unwrap() error {
+	return e.cause
+}
+
+// ProviderError is the failure of a single provider (returned with the results for auditing)
+type ProviderError struct {
+	Err        error         `json:"-"`           // Error returned by the provider
+	Latency    time.Duration `json:"latency"`     // How long the provider took to fail
+	Name       string        `json:"name"`        // Display name of the provider
+	Provider   Provider      `json:"provider"`    // Provider constant (ProviderCustom if not built-in)
+	StatusCode int           `json:"status_code"` // HTTP status of the last request (zero if unknown)
+}
+
+// newProviderError will return the ProviderError for the provider
+// (the status code is taken from the error if the provider attached the last request)
+func newProviderError(provider RateProvider, err error, latency time.Duration) *ProviderError {
+	return &ProviderError{
+		Err:        err,
+		Latency:    latency,
+		Name:       provider.Name(),
+		Provider:   providerType(provider),
+		StatusCode: errorStatusCode(err),
+	}
+}
+
+// newFiatRatesError will return the ProviderError for a failed fiat rate request
+// (named after the fiat rate source, see fiatRatesName)
+func newFiatRatesError(fiatRates FiatRateSource, err error, latency time.Duration) *ProviderError {
+	providerErr := &ProviderError{
+		Err:        err,
+		Latency:    latency,
+		Name:       fiatRatesName(fiatRates),
+		Provider:   ProviderCustom,
+		StatusCode: errorStatusCode(err),
+	}
+	if _, ok := fiatRates.(*coinPaprikaFiatRates); ok {
+		providerErr.Provider = ProviderCoinPaprika
+	}
+	return providerErr
+}
+
+// errorStatusCode will return the HTTP status attached to the error (zero if unknown)
+func errorStatusCode(err error) int {
+	var reqErr *requestError
+	var httpErr *ProviderHTTPError
+	if errors.As(err, &reqErr) {
+		return reqErr.statusCode
+	} else if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
+
+// Error will return the error message (prefixed with the provider name)
+func (e *ProviderError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap will return the underlying error (for errors.Is and errors.As)
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON will marshal the provider error (including the error message)
+func (e *ProviderError) MarshalJSON() ([]byte, error) {
+	type providerError ProviderError
+	return json.Marshal(&struct {
+		*providerError
+		Message string `json:"error"`
+	}{
+		providerError: (*providerError)(e),
+		Message:       e.Err.Error(),
+	})
+}
+
+// ProviderErrors is the failures of every provider that was attempted (in order).
+// It is returned when all the providers fail, and attached to results when a later provider succeeds
+type ProviderErrors []*ProviderError
+
+// Error will return all the error messages
+func (e ProviderErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return "all providers failed: " + strings.Join(messages, "; ")
+}
+
+// Is will return true if any of the provider errors matches the target (for errors.Is)
+func (e ProviderErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As will find the first provider error that matches the target (for errors.As)
+func (e ProviderErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestError is an error from a provider request with the HTTP status of the last request
+type requestError struct {
+	err        error // Error from the request
+	statusCode int   // HTTP status of the last request
+}
+
+// withLastRequest will attach the HTTP status of the last request to the error (if there is one)
+func withLastRequest(err error, request *lastRequest) error {
+	if err == nil || request == nil || request.StatusCode == 0 {
+		return err
+	}
+	return &requestError{err: err, statusCode: request.StatusCode}
+}
+
+// Error will return the error message
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap will return the underlying error (for errors.Is and errors.As)
+func (e *requestError) Unwrap() error {
+	return e.err
+}
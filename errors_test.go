@@ -0,0 +1,203 @@
+package bsvrates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProviderError will test the ProviderError type
+func TestProviderError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error and unwrap", func(t *testing.T) {
+		cause := errors.New("some error occurred")
+		err := newProviderError(&mockRateProvider{name: "in-house"}, cause, time.Second)
+		assert.Equal(t, "in-house", err.Name)
+		assert.Equal(t, ProviderCustom, err.Provider)
+		assert.Equal(t, time.Second, err.Latency)
+		assert.Equal(t, 0, err.StatusCode)
+		assert.Equal(t, "in-house: some error occurred", err.Error())
+		assert.True(t, errors.Is(err, cause))
+	})
+
+	t.Run("status code from the last request", func(t *testing.T) {
+		cause := withLastRequest(errors.New("bad response"), &lastRequest{StatusCode: http.StatusBadGateway})
+		err := newProviderError(&mockRateProvider{name: "in-house"}, cause, 0)
+		assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+		assert.Equal(t, "in-house: bad response", err.Error())
+	})
+
+	t.Run("marshal json", func(t *testing.T) {
+		err := newProviderError(&mockRateProvider{name: "in-house"}, errors.New("failed"), time.Millisecond)
+		data, marshalErr := json.Marshal(err)
+		assert.NoError(t, marshalErr)
+		assert.JSONEq(t,
+			`{"error":"failed","latency":1000000,"name":"in-house","provider":255,"status_code":0}`,
+			string(data),
+		)
+	})
+}
+
+// TestProviderErrors will test the ProviderErrors type
+func TestProviderErrors(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockRateProvider{name: "in-house"}
+	stale := newProviderError(provider, fmt.Errorf("%w: test", ErrStaleQuote), 0)
+	failed := newProviderError(provider, withLastRequest(
+		errors.New("bad response"), &lastRequest{StatusCode: http.StatusTooManyRequests},
+	), 0)
+
+	t.Run("error message", func(t *testing.T) {
+		errs := ProviderErrors{failed, stale}
+		assert.Equal(t,
+			"all providers failed: in-house: bad response; in-house: quote is older than the maximum rate age: test",
+			errs.Error(),
+		)
+	})
+
+	t.Run("errors.Is", func(t *testing.T) {
+		assert.True(t, errors.Is(ProviderErrors{failed, stale}, ErrStaleQuote))
+		assert.False(t, errors.Is(ProviderErrors{failed}, ErrStaleQuote))
+		assert.False(t, errors.Is(ProviderErrors{}, ErrStaleQuote))
+	})
+
+	t.Run("errors.As", func(t *testing.T) {
+		var providerErr *ProviderError
+		assert.True(t, errors.As(ProviderErrors{failed, stale}, &providerErr))
+		assert.Equal(t, http.StatusTooManyRequests, providerErr.StatusCode)
+	})
+}
+
+// TestWithLastRequest will test the method withLastRequest()
+func TestWithLastRequest(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("failed")
+	assert.Nil(t, withLastRequest(nil, &lastRequest{StatusCode: http.StatusOK}))
+	assert.Equal(t, cause, withLastRequest(cause, nil))
+	assert.Equal(t, cause, withLastRequest(cause, &lastRequest{}))
+	assert.True(t, errors.Is(withLastRequest(cause, &lastRequest{StatusCode: http.StatusOK}), cause))
+}
+
+// TestProviderHTTPError will test the ProviderHTTPError type
+func TestProviderHTTPError(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		statusCode          int
+		expectedMessage     string
+		expectedRateLimited bool
+	}{
+		{http.StatusTooManyRequests, "bad response from provider: 429", true},
+		{http.StatusBadGateway, "bad response from provider: 502", false},
+		{http.StatusNotFound, "bad response from provider: 404", false},
+	}
+	for _, test := range tests {
+		err := &ProviderHTTPError{Method: http.MethodGet, StatusCode: test.statusCode}
+		assert.Equal(t, test.expectedMessage, err.Error())
+		assert.Equal(t, test.expectedRateLimited, errors.Is(err, ErrRateLimited))
+		assert.Equal(t, test.expectedRateLimited, errors.Is(fmt.Errorf("wrapped: %w", err), ErrRateLimited))
+	}
+}
+
+// TestParseRetryAfter will test the method parseRetryAfter()
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 6, 28, 19, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		value         string
+		expectedDelay time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{" 5 ", 5 * time.Second},
+		{"0", 0},
+		{"-10", 0},
+		{"Sun, 28 Jun 2020 19:01:30 GMT", 90 * time.Second},
+		{"Sun, 28 Jun 2020 18:59:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedDelay, parseRetryAfter(test.value, now), test.value)
+	}
+}
+
+// TestErrorRetryAfter will test the method errorRetryAfter()
+func TestErrorRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	httpErr := &ProviderHTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}
+	assert.Equal(t, time.Minute, errorRetryAfter(httpErr))
+	assert.Equal(t, time.Minute, errorRetryAfter(fmt.Errorf("wrapped: %w", httpErr)))
+	assert.Equal(t, time.Duration(0), errorRetryAfter(errors.New("failed")))
+	assert.Equal(t, time.Duration(0), errorRetryAfter(nil))
+}
+
+// TestKindError will test the sentinel matching errors
+func TestKindError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("message and sentinel", func(t *testing.T) {
+		err := newKindError(ErrUnsupportedCurrency, "currency [%s] is not supported by any provider", "eur")
+		assert.EqualError(t, err, "currency [eur] is not supported by any provider")
+		assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+		assert.False(t, errors.Is(err, ErrInvalidAmount))
+		assert.Nil(t, errors.Unwrap(err))
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		cause := &json.SyntaxError{}
+		err := newDecodeError(cause)
+		assert.True(t, errors.Is(err, ErrDecode))
+
+		var syntaxErr *json.SyntaxError
+		assert.True(t, errors.As(err, &syntaxErr))
+	})
+}
+
+// TestErrors_Sentinels will test the sentinel errors returned by the package
+func TestErrors_Sentinels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid amount", func(t *testing.T) {
+		_, err := ConvertPriceToSatoshis(150, 0)
+		assert.EqualError(t, err, "an amount must be set")
+		assert.True(t, errors.Is(err, ErrInvalidAmount))
+	})
+
+	t.Run("invalid rate", func(t *testing.T) {
+		_, err := ConvertPriceToSatoshis(0, 1)
+		assert.True(t, errors.Is(err, ErrInvalidRate))
+	})
+
+	t.Run("unsupported currency - transform", func(t *testing.T) {
+		_, err := TransformCurrencyToInt(1, CurrencyEuro)
+		assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+	})
+
+	t.Run("unsupported currency - rate", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		_, _, err := client.GetRate(context.Background(), CurrencyEuro)
+		assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+
+		_, _, err = client.GetRate(context.Background(), CurrencyBitcoin)
+		assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+	})
+
+	t.Run("rate limited - all providers", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaRateLimited{}, ProviderCoinPaprika)
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.False(t, errors.Is(err, ErrDecode))
+	})
+}
@@ -13,7 +13,7 @@ import (
 func main() {
 
 	// Create a new client (custom providers)
-	client := bsvrates.NewClient(nil, nil, bsvrates.ProviderWhatsOnChain, bsvrates.ProviderCoinPaprika)
+	client := bsvrates.NewClient(nil, nil, bsvrates.ProviderWhatsOnChain, bsvrates.ProviderCoinPaprika, bsvrates.ProviderCoinGecko)
 
 	// Get rates
 	rate, provider, _ := client.GetRate(context.Background(), bsvrates.CurrencyDollars)
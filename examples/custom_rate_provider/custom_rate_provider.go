@@ -0,0 +1,49 @@
+/*
+Package main is an example of using the go-bsvrates package with a custom rate provider
+*/
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/tonicpow/go-bsvrates"
+)
+
+// fixedProvider is an example of an in-house provider (always returns the same rate)
+type fixedProvider struct{}
+
+// Name will return the display name of the provider
+func (f *fixedProvider) Name() string {
+	return "FixedProvider"
+}
+
+// SupportsCurrency will return true if the provider can quote the currency (USD only)
+func (f *fixedProvider) SupportsCurrency(currency bsvrates.Currency) bool {
+	return currency == bsvrates.CurrencyDollars
+}
+
+// GetRate will return the fixed rate
+func (f *fixedProvider) GetRate(_ context.Context, _ bsvrates.Currency) (float64, error) {
+	return 50.00, nil
+}
+
+// GetConversion will return the satoshis using the fixed rate
+func (f *fixedProvider) GetConversion(ctx context.Context, currency bsvrates.Currency, amount float64) (int64, error) {
+	rate, _ := f.GetRate(ctx, currency)
+	return bsvrates.ConvertPriceToSatoshis(rate, amount)
+}
+
+func main() {
+
+	// Add the custom provider (used after the built-in providers)
+	options := bsvrates.DefaultClientOptions()
+	options.CustomProviders = []bsvrates.RateProvider{&fixedProvider{}}
+
+	// Create a new client (custom provider is the fail-over for WhatsOnChain)
+	client := bsvrates.NewClient(options, nil, bsvrates.ProviderWhatsOnChain)
+
+	// Get rates
+	rate, provider, _ := client.GetRate(context.Background(), bsvrates.CurrencyDollars)
+	log.Printf("found rate: %v %s from provider: %s", rate, bsvrates.CurrencyToName(bsvrates.CurrencyDollars), provider.Name())
+}
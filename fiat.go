@@ -0,0 +1,62 @@
+package bsvrates
+
+import (
+	"context"
+)
+
+// FiatRateSource is the interface for any source of fiat->fiat exchange rates.
+// It is used to cross-convert amounts for providers that only quote USD.
+// Add a Name() string method to name the source in the conversion errors
+type FiatRateSource interface {
+	GetFiatRate(ctx context.Context, from, to Currency) (rate float64, err error)
+}
+
+// defaultFiatRatesName is the name of a fiat rate source without a Name() method
+const defaultFiatRatesName = "fiat rates"
+
+// fiatRatesName will return the display name of the fiat rate source (used in the conversion errors)
+func fiatRatesName(fiatRates FiatRateSource) string {
+	if named, ok := fiatRates.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return defaultFiatRatesName
+}
+
+// coinPaprikaFiatRates is the default FiatRateSource (uses the Coin Paprika price converter)
+type coinPaprikaFiatRates struct {
+	client *Client // Parent client (uses the current Coin Paprika client)
+}
+
+// Name will return the display name of the fiat rate source
+func (f *coinPaprikaFiatRates) Name() string {
+	return ProviderCoinPaprika.Name() + " " + defaultFiatRatesName
+}
+
+// GetFiatRate will return the amount of "to" currency for one unit of "from" currency
+func (f *coinPaprikaFiatRates) GetFiatRate(ctx context.Context, from, to Currency) (rate float64, err error) {
+
+	// Only fiat currencies are supported
+	if !from.IsFiat() || !to.IsFiat() {
+		err = newKindError(ErrUnsupportedCurrency, "fiat rate from [%s] to [%s] is not supported", from.Name(), to.Name())
+		return
+	} else if from == to {
+		return 1, nil
+	}
+
+	// Get the currency IDs
+	fromID, _ := f.client.CoinPaprika().GetBaseAmountAndCurrencyID(from.Name(), 1)
+	toID, _ := f.client.CoinPaprika().GetBaseAmountAndCurrencyID(to.Name(), 1)
+	if len(fromID) == 0 || len(toID) == 0 {
+		err = newKindError(ErrUnsupportedCurrency, "fiat rate from [%s] to [%s] is not supported", from.Name(), to.Name())
+		return
+	}
+
+	// Convert one unit of the currency
+	var response *PriceConversionResponse
+	if response, err = f.client.CoinPaprika().GetPriceConversion(
+		ctx, fromID, toID, 1,
+	); err == nil && response != nil {
+		rate = response.Price
+	}
+	return
+}
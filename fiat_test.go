@@ -0,0 +1,49 @@
+package bsvrates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCoinPaprikaFiatRates_GetFiatRate will test the method GetFiatRate()
+func TestCoinPaprikaFiatRates_GetFiatRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid fiat rate", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		rate, err := client.FiatRates().GetFiatRate(context.Background(), CurrencyEuro, CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.1, rate)
+	})
+
+	t.Run("same currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
+		assert.NotNil(t, client)
+
+		rate, err := client.FiatRates().GetFiatRate(context.Background(), CurrencyEuro, CurrencyEuro)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1), rate)
+	})
+
+	t.Run("not a fiat currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		rate, err := client.FiatRates().GetFiatRate(context.Background(), CurrencyBitcoin, CurrencyDollars)
+		assert.Error(t, err)
+		assert.Equal(t, float64(0), rate)
+	})
+
+	t.Run("unknown currency id", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
+		assert.NotNil(t, client)
+
+		rate, err := client.FiatRates().GetFiatRate(context.Background(), CurrencyEuro, CurrencyDollars)
+		assert.Error(t, err)
+		assert.Equal(t, float64(0), rate)
+	})
+}
@@ -0,0 +1,235 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultHealthWindow is the number of recent requests used for the rolling success rate
+const defaultHealthWindow = 100
+
+// minHealthySuccessRate is the rolling success rate below which a provider is degraded
+const minHealthySuccessRate = 0.5
+
+// errNoHealthCheck is returned when a provider cannot be checked
+var errNoHealthCheck = errors.New("provider cannot be checked")
+
+// HealthStatus is the health of a provider
+type HealthStatus uint8
+
+// HealthStatus constants for the different health states.
+// Leave the start and last constants in place
+const (
+	HealthUnknown HealthStatus = iota // 0 (the provider cannot be checked)
+
+	HealthHealthy   // 1 (the health check passed)
+	HealthDegraded  // 2 (rate limited, the circuit is not closed or recent requests are failing)
+	HealthUnhealthy // 3 (the health check failed)
+	healthLast      // 4
+)
+
+// Name will return the display name for the given health status
+func (s HealthStatus) Name() string {
+	switch s {
+	case HealthHealthy:
+		return "healthy"
+	case HealthDegraded:
+		return "degraded"
+	case HealthUnhealthy:
+		return "unhealthy"
+	case HealthUnknown:
+		return "unknown"
+	case healthLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// IsUsable will return true if the provider can be used (healthy or degraded)
+func (s HealthStatus) IsUsable() bool {
+	return s == HealthHealthy || s == HealthDegraded
+}
+
+// ProviderHealth is the health of a single provider
+type ProviderHealth struct {
+	CircuitState CircuitState  `json:"circuit_state"` // State of the circuit breaker (see ClientOptions.CircuitBreakerThreshold)
+	Error        string        `json:"error"`         // Error from the health check (empty if it passed)
+	LastError    string        `json:"last_error"`    // Most recent error from the provider (health checks and requests)
+	LastErrorAt  time.Time     `json:"last_error_at"` // When the most recent error happened (zero if none)
+	Latency      time.Duration `json:"latency"`       // How long the health check took
+	Name         string        `json:"name"`          // Display name of the provider
+	Provider     Provider      `json:"provider"`      // Provider constant (ProviderCustom if not built-in)
+	Requests     int           `json:"requests"`      // Number of recent requests in the success rate
+	Status       HealthStatus  `json:"status"`        // Health of the provider
+	SuccessRate  float64       `json:"success_rate"`  // Rolling success rate of the recent requests (0 to 1, zero if none)
+}
+
+// HealthReport is the health of every configured provider
+type HealthReport struct {
+	CheckedAt time.Time         `json:"checked_at"` // When the health check started
+	Healthy   bool              `json:"healthy"`    // True if at least one provider is usable
+	Providers []*ProviderHealth `json:"providers"`  // Health of each provider (in order for fail-over)
+}
+
+// HealthCheck will check every configured provider (at the same time) and return the health of each
+// provider, including the rolling success rate of its recent requests.
+//
+// Providers that implement HealthChecker are checked with a cheap request, otherwise a USD rate
+// is requested. Health checks are limited by ClientOptions.RateLimits, but skip the circuit breaker
+func (c *Client) HealthCheck(ctx context.Context) (report *HealthReport) {
+	providers := c.RateProviders()
+	report = &HealthReport{
+		CheckedAt: time.Now().UTC(),
+		Providers: make([]*ProviderHealth, len(providers)),
+	}
+
+	// Check the providers at the same time
+	var wg sync.WaitGroup
+	for index, provider := range providers {
+		wg.Add(1)
+		go func(index int, provider RateProvider) {
+			defer wg.Done()
+			report.Providers[index] = c.checkProvider(ctx, provider)
+		}(index, provider)
+	}
+	wg.Wait()
+
+	// Healthy if any provider is usable
+	for _, health := range report.Providers {
+		if health.Status.IsUsable() {
+			report.Healthy = true
+		}
+	}
+	return
+}
+
+// checkProvider will check the provider and return its health
+func (c *Client) checkProvider(ctx context.Context, provider RateProvider) (health *ProviderHealth) {
+	health = &ProviderHealth{
+		CircuitState: c.breakers.state(provider.Name()),
+		Name:         provider.Name(),
+		Provider:     providerType(provider),
+	}
+
+	// Check the provider (limited by the rate limiter)
+	start := time.Now()
+	err := c.limiters.wait(ctx, provider.Name())
+	if err == nil {
+		requested := time.Now()
+		if err = healthCheck(ctx, provider); !errors.Is(err, errNoHealthCheck) {
+			c.health.record(provider.Name(), err, time.Since(requested))
+			c.limiters.record(provider.Name(), err)
+		}
+	}
+	health.Latency = time.Since(start)
+
+	// Set the status
+	health.SuccessRate, health.Requests, health.LastError, health.LastErrorAt = c.health.get(provider.Name()).summary()
+	switch {
+	case errors.Is(err, errNoHealthCheck):
+		health.Status = HealthUnknown
+	case err != nil:
+		health.Error = err.Error()
+		if health.Status = HealthUnhealthy; errors.Is(err, ErrRateLimited) {
+			health.Status = HealthDegraded
+		}
+		c.logHealthCheck(ctx, health)
+	case health.CircuitState != CircuitClosed,
+		health.Requests > 0 && health.SuccessRate < minHealthySuccessRate:
+		health.Status = HealthDegraded
+	default:
+		health.Status = HealthHealthy
+	}
+	return
+}
+
+// healthCheck will check the provider (a USD rate is requested if it does not implement HealthChecker)
+func healthCheck(ctx context.Context, provider RateProvider) (err error) {
+	if checker, ok := provider.(HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	} else if !provider.SupportsCurrency(CurrencyDollars) {
+		return errNoHealthCheck
+	}
+	_, err = getQuote(ctx, provider, CurrencyDollars)
+	return
+}
+
+// providerStats is the rolling window of recent request results for a single provider
+type providerStats struct {
+	ewmaLatency time.Duration // EWMA latency of the requests (adaptive ordering)
+	ewmaSuccess float64       // EWMA success rate (adaptive ordering)
+	lastError   string        // Most recent error
+	lastErrorAt time.Time     // When the most recent error happened
+	mu          sync.Mutex    // Guards the stats
+	next        int           // Next position in the window
+	observedAt  time.Time     // When the last request was observed for the EWMA
+	results     []bool        // Recent results (true if it succeeded)
+	scored      bool          // At least one request was observed for the EWMA
+}
+
+// summary will return the rolling success rate, the number of requests and the last error
+func (s *providerStats) summary() (successRate float64, requests int, lastError string, lastErrorAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var succeeded int
+	for _, result := range s.results {
+		if result {
+			succeeded++
+		}
+	}
+	if requests = len(s.results); requests > 0 {
+		successRate = float64(succeeded) / float64(requests)
+	}
+	return successRate, requests, s.lastError, s.lastErrorAt
+}
+
+// healthStats is the recent request results for each provider (keyed by provider name)
+type healthStats struct {
+	mu     sync.Mutex                // Guards the stats map
+	stats  map[string]*providerStats // Stats by provider name
+	window int                       // Number of recent requests to keep
+}
+
+// newHealthStats will return the health stats
+func newHealthStats() *healthStats {
+	return &healthStats{
+		stats:  make(map[string]*providerStats),
+		window: defaultHealthWindow,
+	}
+}
+
+// get will return the stats for the provider (created if not found)
+func (h *healthStats) get(name string) *providerStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stats, ok := h.stats[name]
+	if !ok {
+		stats = new(providerStats)
+		h.stats[name] = stats
+	}
+	return stats
+}
+
+// record will record the result (and latency) of a request to the provider
+// (requests cancelled by the caller are not counted)
+func (h *healthStats) record(name string, err error, latency time.Duration) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	stats := h.get(name)
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if len(stats.results) < h.window {
+		stats.results = append(stats.results, err == nil)
+	} else {
+		stats.results[stats.next] = err == nil
+	}
+	stats.next = (stats.next + 1) % h.window
+	if err != nil {
+		stats.lastError, stats.lastErrorAt = err.Error(), time.Now().UTC()
+	}
+	stats.observe(err, latency)
+}
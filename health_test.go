@@ -0,0 +1,194 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHealthStatus_Name will test the methods Name() and IsUsable()
+func TestHealthStatus_Name(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		status         HealthStatus
+		expectedName   string
+		expectedUsable bool
+	}{
+		{HealthUnknown, "unknown", false},
+		{HealthHealthy, "healthy", true},
+		{HealthDegraded, "degraded", true},
+		{HealthUnhealthy, "unhealthy", false},
+		{healthLast, "", false},
+		{123, "", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedName, test.status.Name())
+		assert.Equal(t, test.expectedUsable, test.status.IsUsable())
+	}
+}
+
+// TestHealthStats will test the rolling success rate
+func TestHealthStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no requests", func(t *testing.T) {
+		successRate, requests, lastError, lastErrorAt := newHealthStats().get("test").summary()
+		assert.Equal(t, float64(0), successRate)
+		assert.Equal(t, 0, requests)
+		assert.Equal(t, "", lastError)
+		assert.True(t, lastErrorAt.IsZero())
+	})
+
+	t.Run("rolling window", func(t *testing.T) {
+		stats := newHealthStats()
+		stats.window = 4
+		stats.record("test", errors.New("failed"), 0)
+		stats.record("test", errors.New("failed again"), 0)
+		stats.record("test", nil, 0)
+		stats.record("test", nil, 0)
+
+		successRate, requests, lastError, lastErrorAt := stats.get("test").summary()
+		assert.Equal(t, 0.5, successRate)
+		assert.Equal(t, 4, requests)
+		assert.Equal(t, "failed again", lastError)
+		assert.False(t, lastErrorAt.IsZero())
+
+		// The oldest results are replaced
+		stats.record("test", nil, 0)
+		stats.record("test", nil, 0)
+		successRate, requests, _, _ = stats.get("test").summary()
+		assert.Equal(t, float64(1), successRate)
+		assert.Equal(t, 4, requests)
+	})
+
+	t.Run("cancelled requests are not counted", func(t *testing.T) {
+		stats := newHealthStats()
+		stats.record("test", context.Canceled, 0)
+		_, requests, _, _ := stats.get("test").summary()
+		assert.Equal(t, 0, requests)
+	})
+}
+
+// TestClient_HealthCheck will test the method HealthCheck()
+func TestClient_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("built-in providers are healthy", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		report := client.HealthCheck(context.Background())
+		assert.True(t, report.Healthy)
+		assert.False(t, report.CheckedAt.IsZero())
+		if assert.Equal(t, 2, len(report.Providers)) {
+			assert.Equal(t, ProviderCoinPaprika, report.Providers[0].Provider)
+			assert.Equal(t, ProviderWhatsOnChain, report.Providers[1].Provider)
+			for _, health := range report.Providers {
+				assert.Equal(t, HealthHealthy, health.Status)
+				assert.Equal(t, CircuitClosed, health.CircuitState)
+				assert.Equal(t, "", health.Error)
+				assert.Equal(t, 1, health.Requests)
+				assert.Equal(t, float64(1), health.SuccessRate)
+			}
+		}
+	})
+
+	t.Run("failed provider is unhealthy", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		client := NewClient(options, nil, ProviderWhatsOnChain)
+		client.SetWhatsOnChain(&mockWOCDown{})
+
+		report := client.HealthCheck(context.Background())
+		assert.False(t, report.Healthy)
+		if assert.Equal(t, 1, len(report.Providers)) {
+			health := report.Providers[0]
+			assert.Equal(t, HealthUnhealthy, health.Status)
+			assert.Equal(t, "service unavailable", health.Error)
+			assert.Equal(t, "service unavailable", health.LastError)
+			assert.Equal(t, float64(0), health.SuccessRate)
+		}
+
+		entry := logger.find("warn", "provider health check failed")
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, "WhatsOnChain", entry.value("provider"))
+			assert.Equal(t, "unhealthy", entry.value("status"))
+		}
+	})
+
+	t.Run("custom providers", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{
+			&mockRateProvider{name: "in-house", rate: 150},
+			&mockRateProvider{name: "down"},
+			&mockRateProvider{name: "euro", currencies: []Currency{CurrencyEuro}, rate: 140},
+		}
+		client := NewClient(options, nil)
+
+		report := client.HealthCheck(context.Background())
+		assert.True(t, report.Healthy)
+		if assert.Equal(t, 3, len(report.Providers)) {
+			assert.Equal(t, HealthHealthy, report.Providers[0].Status)
+			assert.Equal(t, ProviderCustom, report.Providers[0].Provider)
+			assert.Equal(t, HealthUnhealthy, report.Providers[1].Status)
+			assert.Equal(t, "request to down fails... 502", report.Providers[1].Error)
+			assert.Equal(t, HealthUnknown, report.Providers[2].Status)
+			assert.Equal(t, 0, report.Providers[2].Requests)
+		}
+	})
+
+	t.Run("open circuit is degraded", func(t *testing.T) {
+		flaky := &mockCheckedProvider{mockRateProvider: mockRateProvider{name: "flaky"}}
+		options := DefaultClientOptions()
+		options.CircuitBreakerThreshold = 1
+		options.CustomProviders = []RateProvider{flaky}
+		client := NewClient(options, nil)
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+
+		report := client.HealthCheck(context.Background())
+		if assert.Equal(t, 1, len(report.Providers)) {
+			health := report.Providers[0]
+			assert.Equal(t, HealthDegraded, health.Status)
+			assert.Equal(t, CircuitOpen, health.CircuitState)
+			assert.Equal(t, "", health.Error)
+			assert.Equal(t, "request to flaky fails... 502", health.LastError)
+			assert.Equal(t, 2, health.Requests)
+			assert.Equal(t, 0.5, health.SuccessRate)
+		}
+		assert.True(t, report.Healthy)
+	})
+
+	t.Run("failing requests are degraded", func(t *testing.T) {
+		flaky := &mockCheckedProvider{mockRateProvider: mockRateProvider{name: "flaky"}}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{flaky}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 2; i++ {
+			_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.Error(t, err)
+		}
+
+		report := client.HealthCheck(context.Background())
+		assert.Equal(t, HealthDegraded, report.Providers[0].Status)
+		assert.InDelta(t, 0.333, report.Providers[0].SuccessRate, 0.001)
+	})
+
+	t.Run("rate limited is degraded", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockCheckedProvider{mockRateProvider: mockRateProvider{name: "limited"}}}
+		options.RateLimits = map[string]RateLimit{"limited": {MonthlyBudget: 1}}
+		client := NewClient(options, nil)
+
+		report := client.HealthCheck(context.Background())
+		assert.Equal(t, HealthHealthy, report.Providers[0].Status)
+
+		report = client.HealthCheck(context.Background())
+		assert.Equal(t, HealthDegraded, report.Providers[0].Status)
+		assert.Equal(t, "monthly budget of 1 requests is used", report.Providers[0].Error)
+	})
+}
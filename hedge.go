@@ -0,0 +1,87 @@
+package bsvrates
+
+import (
+	"context"
+	"time"
+)
+
+// providerAttempt is the result of a request to a single provider (set by the attempt)
+type providerAttempt struct {
+	crossRate float64       // Currency->USD rate used (conversions only)
+	err       error         // Error from the provider
+	latency   time.Duration // How long the attempt took
+	quote     *Quote        // Quote from the provider (rates only)
+	satoshis  int64         // Satoshis from the provider (conversions only)
+	status    CacheStatus   // Cache status of the rate used
+}
+
+// hedgeResult is the outcome of an attempt
+type hedgeResult struct {
+	err   error // Error from the attempt (nil if it succeeded)
+	index int   // Index of the attempt
+}
+
+// hedge will run the attempts (one per provider, in order) until one succeeds. It returns the
+// index of the attempt that succeeded (-1 if they all failed) and the indexes of the attempts
+// that failed (in the order they failed).
+//
+// The next attempt is started as soon as an attempt fails, or when the running attempts have not
+// answered within the delay (if set). Once an attempt succeeds, the others are cancelled.
+// An attempt must only set its own result, which is safe to read for the returned indexes
+func hedge(ctx context.Context, delay time.Duration, count int,
+	attempt func(ctx context.Context, index int) error) (winner int, failed []int) {
+
+	winner = -1
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so that cancelled attempts never block
+	results := make(chan hedgeResult, count)
+
+	// Start the next attempt (and the hedge timer if there is another attempt after it)
+	var started, running int
+	var timer *time.Timer
+	var hedged <-chan time.Time
+	next := func() {
+		go func(index int) {
+			results <- hedgeResult{err: attempt(ctx, index), index: index}
+		}(started)
+		started++
+		running++
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if hedged = nil; delay > 0 && started < count {
+			timer = time.NewTimer(delay)
+			hedged = timer.C
+		}
+	}
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	// Wait for an attempt to succeed (or all of them to fail)
+	for started < count || running > 0 {
+		if running == 0 {
+			next()
+		}
+		select {
+		case result := <-results:
+			running--
+			if result.err == nil {
+				winner = result.index
+				return
+			}
+			failed = append(failed, result.index)
+			if started < count {
+				next()
+			}
+		case <-hedged:
+			next()
+		}
+	}
+	return
+}
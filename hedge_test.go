@@ -0,0 +1,197 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHedge will test the method hedge()
+func TestHedge(t *testing.T) {
+	t.Parallel()
+
+	failed := errors.New("provider failed")
+
+	t.Run("no attempts", func(t *testing.T) {
+		winner, failures := hedge(context.Background(), 0, 0, func(context.Context, int) error {
+			return nil
+		})
+		assert.Equal(t, -1, winner)
+		assert.Equal(t, 0, len(failures))
+	})
+
+	t.Run("sequential - first success wins", func(t *testing.T) {
+		var running, maxRunning int64
+		var calls []int
+		winner, failures := hedge(context.Background(), 0, 3, func(_ context.Context, index int) error {
+			if current := atomic.AddInt64(&running, 1); current > atomic.LoadInt64(&maxRunning) {
+				atomic.StoreInt64(&maxRunning, current)
+			}
+			defer atomic.AddInt64(&running, -1)
+			calls = append(calls, index)
+			if index < 1 {
+				return failed
+			}
+			return nil
+		})
+		assert.Equal(t, 1, winner)
+		assert.Equal(t, []int{0}, failures)
+		assert.Equal(t, []int{0, 1}, calls)
+		assert.Equal(t, int64(1), atomic.LoadInt64(&maxRunning))
+	})
+
+	t.Run("sequential - all fail", func(t *testing.T) {
+		winner, failures := hedge(context.Background(), 0, 3, func(context.Context, int) error {
+			return failed
+		})
+		assert.Equal(t, -1, winner)
+		assert.Equal(t, []int{0, 1, 2}, failures)
+	})
+
+	t.Run("hedged - slow attempt is cancelled", func(t *testing.T) {
+		cancelled := make(chan struct{})
+		winner, failures := hedge(context.Background(), 10*time.Millisecond, 2, func(ctx context.Context, index int) error {
+			if index == 0 {
+				<-ctx.Done()
+				close(cancelled)
+				return ctx.Err()
+			}
+			return nil
+		})
+		assert.Equal(t, 1, winner)
+		assert.Equal(t, 0, len(failures))
+
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("slow attempt was not cancelled")
+		}
+	})
+
+	t.Run("hedged - fast provider is not hedged", func(t *testing.T) {
+		var calls int64
+		winner, _ := hedge(context.Background(), time.Second, 2, func(context.Context, int) error {
+			atomic.AddInt64(&calls, 1)
+			return nil
+		})
+		assert.Equal(t, 0, winner)
+		assert.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	})
+
+	t.Run("hedged - failure starts the next attempt", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		start := time.Now()
+		winner, failures := hedge(context.Background(), 20*time.Millisecond, 3, func(ctx context.Context, index int) error {
+			switch index {
+			case 0:
+				select {
+				case <-release:
+				case <-ctx.Done():
+				}
+				return failed
+			case 1:
+				return failed
+			default:
+				return nil
+			}
+		})
+		assert.Equal(t, 2, winner)
+		assert.Equal(t, []int{1}, failures)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}
+
+// TestClient_HedgeDelay will test hedged provider requests
+func TestClient_HedgeDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate - slow provider is hedged", func(t *testing.T) {
+		slow := newMockSlowProvider()
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{slow, &mockRateProvider{name: "in-house", rate: 125}}
+		options.HedgeDelay = 10 * time.Millisecond
+		client := NewClient(options, nil)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "in-house", result.ProviderName)
+		assert.Equal(t, "125", result.Rate.String())
+		assert.Equal(t, 0, len(result.Errors))
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt64(&slow.cancelled) == 1
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("rate - cancelled attempt is not counted in the metrics", func(t *testing.T) {
+		metrics := newMockMetrics()
+		slow := newMockSlowProvider()
+		options := newMockOptions(slow, &mockRateProvider{name: "in-house", rate: 125})
+		options.HedgeDelay = 10 * time.Millisecond
+		options.Metrics = metrics
+		client := NewClient(options, nil)
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt64(&slow.cancelled) == 1
+		}, time.Second, 5*time.Millisecond)
+		assert.Never(t, func() bool {
+			metrics.mu.Lock()
+			defer metrics.mu.Unlock()
+			return len(metrics.requests) != 1
+		}, 50*time.Millisecond, 5*time.Millisecond)
+		assert.Equal(t, "in-house", metrics.requests[0].provider)
+	})
+
+	t.Run("rate - first provider answers in time", func(t *testing.T) {
+		fallback := &mockRateProvider{name: "fallback", rate: 125}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house", rate: 150}, fallback}
+		options.HedgeDelay = time.Second
+		client := NewClient(options, nil)
+
+		rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(150), rate)
+		assert.Equal(t, int64(0), fallback.rateCalls())
+	})
+
+	t.Run("rate - all providers fail", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "first"}, &mockRateProvider{name: "second"}}
+		options.HedgeDelay = 10 * time.Millisecond
+		client := NewClient(options, nil)
+
+		_, providerUsed, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.Equal(t, ProviderCustom, providerUsed)
+
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) {
+			assert.Equal(t, 2, len(errs))
+		}
+	})
+
+	t.Run("conversion - slow provider is hedged", func(t *testing.T) {
+		slow := newMockSlowProvider()
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{slow, &mockRateProvider{name: "in-house", satoshis: 1000}}
+		options.HedgeDelay = 10 * time.Millisecond
+		client := NewClient(options, nil)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "in-house", result.ProviderName)
+		assert.Equal(t, int64(1000), result.Satoshis)
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt64(&slow.cancelled) == 1
+		}, time.Second, 5*time.Millisecond)
+	})
+}
@@ -0,0 +1,56 @@
+package bsvrates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GetHistoricalTickers will return the historical tickers from Coin Paprika given the range of time.
+// If the cache is enabled (ClientOptions.CacheTTL), the results are stored in the cache backend
+// (a cached response has the LastRequest of the original request, with a 200 status)
+func (c *Client) GetHistoricalTickers(ctx context.Context, coinID string, start, end time.Time, limit int,
+	quote tickerQuote, interval tickerInterval) (response *HistoricalResponse, err error) {
+
+	// No cache
+	if c.cache == nil {
+		return c.CoinPaprika().GetHistoricalTickers(ctx, coinID, start, end, limit, quote, interval)
+	}
+
+	// Send end if zero (so the key is not the zero time)
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+
+	// Check the cache
+	key := fmt.Sprintf(
+		"%shistorical:%s:%d:%d:%d:%s:%s",
+		cachePrefix, coinID, start.Unix(), end.Unix(), limit, quote, interval,
+	)
+	if value, found, cacheErr := c.cache.backend.Get(ctx, key); cacheErr == nil && found {
+		var results HistoricalResults
+		if err = json.Unmarshal(value, &results); err == nil {
+			return &HistoricalResponse{
+				LastRequest: &lastRequest{
+					Method:     http.MethodGet,
+					StatusCode: http.StatusOK,
+					URL:        historicalTickersURL(coinID, start, end, limit, quote, interval),
+				},
+				Results: results,
+			}, nil
+		}
+	}
+
+	// Fetch from Coin Paprika and store
+	if response, err = c.CoinPaprika().GetHistoricalTickers(
+		ctx, coinID, start, end, limit, quote, interval,
+	); err != nil || response == nil {
+		return
+	}
+	if value, marshalErr := json.Marshal(response.Results); marshalErr == nil {
+		_ = c.cache.backend.Set(ctx, key, value, c.cache.ttl)
+	}
+	return
+}
@@ -0,0 +1,130 @@
+package bsvrates
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPaprikaHistorical for mocking historical requests
+type mockPaprikaHistorical struct {
+	mockPaprikaValid
+	calls int64      // Number of historical requests (use atomic)
+	end   time.Time  // End time of the last request
+	mu    sync.Mutex // Guards the end time
+}
+
+// GetHistoricalTickers is a mock response
+func (m *mockPaprikaHistorical) GetHistoricalTickers(_ context.Context, _ string, _ time.Time, end time.Time, _ int,
+	_ tickerQuote, _ tickerInterval) (response *HistoricalResponse, err error) {
+	atomic.AddInt64(&m.calls, 1)
+	m.mu.Lock()
+	m.end = end
+	m.mu.Unlock()
+	response = &HistoricalResponse{Results: HistoricalResults{
+		{Price: 107.61, Timestamp: "2019-12-01T00:00:00Z", Volume24h: 391994346},
+		{Price: 106.23, Timestamp: "2019-12-01T01:00:00Z", Volume24h: 391502460},
+	}}
+	return
+}
+
+// lastEnd will return the end time of the last request
+func (m *mockPaprikaHistorical) lastEnd() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.end
+}
+
+// TestClient_GetHistoricalTickers will test the method GetHistoricalTickers()
+func TestClient_GetHistoricalTickers(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)
+	end := time.Date(2021, 1, 2, 1, 1, 1, 1, time.UTC)
+
+	t.Run("cache disabled", func(t *testing.T) {
+		paprika := &mockPaprikaHistorical{}
+		client := newMockClient(nil, &mockWOCValid{}, paprika)
+
+		for i := 0; i < 2; i++ {
+			response, err := client.GetHistoricalTickers(
+				context.Background(), CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval1h,
+			)
+			assert.NoError(t, err)
+			assert.NotNil(t, response)
+			assert.Equal(t, 2, len(response.Results))
+		}
+		assert.Equal(t, int64(2), atomic.LoadInt64(&paprika.calls))
+	})
+
+	t.Run("cache enabled", func(t *testing.T) {
+		paprika := &mockPaprikaHistorical{}
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		client := NewClient(options, nil)
+		client.SetCoinPaprika(paprika)
+
+		for i := 0; i < 2; i++ {
+			response, err := client.GetHistoricalTickers(
+				context.Background(), CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval1h,
+			)
+			assert.NoError(t, err)
+			assert.NotNil(t, response)
+			assert.Equal(t, 2, len(response.Results))
+			assert.Equal(t, 106.23, response.Results[1].Price)
+		}
+		assert.Equal(t, int64(1), atomic.LoadInt64(&paprika.calls))
+
+		// Cache hits have the last request
+		response, err := client.GetHistoricalTickers(
+			context.Background(), CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval1h,
+		)
+		assert.NoError(t, err)
+		if assert.NotNil(t, response.LastRequest) {
+			assert.Equal(t, http.StatusOK, response.LastRequest.StatusCode)
+			assert.Equal(t, http.MethodGet, response.LastRequest.Method)
+			assert.Equal(t, historicalTickersURL(
+				CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval1h,
+			), response.LastRequest.URL)
+		}
+
+		// Different range is a different key
+		_, err = client.GetHistoricalTickers(
+			context.Background(), CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval5m,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), atomic.LoadInt64(&paprika.calls))
+	})
+
+	t.Run("zero end time is now", func(t *testing.T) {
+		paprika := &mockPaprikaHistorical{}
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		client := NewClient(options, nil)
+		client.SetCoinPaprika(paprika)
+
+		_, err := client.GetHistoricalTickers(
+			context.Background(), CoinPaprikaQuoteID, start, time.Time{}, 0, TickerQuoteUSD, TickerInterval1h,
+		)
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(paprika.lastEnd()), time.Minute)
+	})
+
+	t.Run("failed request is not cached", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		client := NewClient(options, nil)
+		client.SetCoinPaprika(&mockPaprikaFailed{})
+
+		response, err := client.GetHistoricalTickers(
+			context.Background(), CoinPaprikaQuoteID, start, end, 0, TickerQuoteUSD, TickerInterval1h,
+		)
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
@@ -2,22 +2,63 @@ package bsvrates
 
 import (
 	"context"
+	"time"
 
 	"github.com/mrz1836/go-whatsonchain"
 )
 
+// RateProvider is the interface for any source of BSV exchange rates (built-in or custom)
+type RateProvider interface {
+	GetConversion(ctx context.Context, currency Currency, amount float64) (satoshis int64, err error)
+	GetRate(ctx context.Context, currency Currency) (rate float64, err error)
+	Name() string
+	SupportsCurrency(currency Currency) bool
+}
+
+// HealthChecker is an optional interface for a RateProvider with a cheap request to check the provider
+// (providers that do not implement it are checked with a USD rate request)
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// QuoteProvider is an optional interface for a RateProvider that can return the quote time
+// (providers that do not implement it only report the rate)
+type QuoteProvider interface {
+	GetQuote(ctx context.Context, currency Currency) (quote *Quote, err error)
+}
+
 // RateService is the rate methods
 type RateService interface {
+	GetAggregatedConversion(ctx context.Context, currency Currency, amount float64, method AggregationMethod) (result *AggregatedConversion, err error)
+	GetAggregatedRate(ctx context.Context, currency Currency, method AggregationMethod) (result *AggregatedRate, err error)
+	GetCachedRate(ctx context.Context, currency Currency) (rate float64, providerUsed Provider, status CacheStatus, err error)
 	GetConversion(ctx context.Context, currency Currency, amount float64) (satoshis int64, providerUsed Provider, err error)
+	GetConversionDetails(ctx context.Context, currency Currency, amount float64) (result *ConversionResult, err error)
 	GetRate(ctx context.Context, currency Currency) (rate float64, providerUsed Provider, err error)
+	GetRateResult(ctx context.Context, currency Currency) (result *RateResult, err error)
+	GetSideConversion(ctx context.Context, currency Currency, amount float64, side QuoteSide, spreadBps float64) (result *ConversionResult, err error)
+	GetTWAP(ctx context.Context, currency Currency, window time.Duration) (result *TWAPResult, err error)
 }
 
 // ClientInterface is the BSVRate client interface
 type ClientInterface interface {
 	RateService
+	AddProvider(provider RateProvider)
+	Bitfinex() BitfinexInterface
+	CircuitState(providerName string) CircuitState
+	CircuitStates() map[string]CircuitState
+	CoinGecko() CoinGeckoInterface
 	CoinPaprika() CoinPaprikaInterface
+	FiatRates() FiatRateSource
+	GetHistoricalTickers(ctx context.Context, coinID string, start, end time.Time, limit int, quote tickerQuote, interval tickerInterval) (response *HistoricalResponse, err error)
+	HealthCheck(ctx context.Context) (report *HealthReport)
 	Providers() []Provider
+	ProviderScores() map[string]float64
+	RateProviders() []RateProvider
+	SetBitfinex(client BitfinexInterface)
+	SetCoinGecko(client CoinGeckoInterface)
 	SetCoinPaprika(client CoinPaprikaInterface)
+	SetFiatRates(fiatRates FiatRateSource)
 	SetWhatsOnChain(client whatsonchain.ChainService)
 	WhatsOnChain() whatsonchain.ChainService
 }
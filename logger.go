@@ -0,0 +1,167 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Logger is the interface for a structured logger (compatible with *slog.Logger).
+// The args are alternating keys and values (IE: "provider", "CoinPaprika")
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// nopLogger is the default Logger (discards everything)
+type nopLogger struct{}
+
+// DebugContext discards the message
+func (nopLogger) DebugContext(context.Context, string, ...interface{}) {}
+
+// ErrorContext discards the message
+func (nopLogger) ErrorContext(context.Context, string, ...interface{}) {}
+
+// InfoContext discards the message
+func (nopLogger) InfoContext(context.Context, string, ...interface{}) {}
+
+// WarnContext discards the message
+func (nopLogger) WarnContext(context.Context, string, ...interface{}) {}
+
+// logger will return the configured logger (defaults to discarding everything)
+func (c *ClientOptions) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+// logAttempt will log a request to a provider
+func (c *Client) logAttempt(ctx context.Context, operation string, provider RateProvider,
+	currency Currency, attempt int) {
+	c.logger.DebugContext(ctx, "requesting "+operation+" from provider",
+		"provider", provider.Name(),
+		"currency", currency.Name(),
+		"attempt", attempt,
+	)
+}
+
+// logFailure will log a provider failure (and the fallback to the next provider if there is one)
+func (c *Client) logFailure(ctx context.Context, operation string, providerErr *ProviderError,
+	currency Currency, fallback bool) {
+	msg := operation + " provider failed"
+	if fallback {
+		msg += ", falling back to the next provider"
+	}
+	c.logger.WarnContext(ctx, msg,
+		"provider", providerErr.Name,
+		"currency", currency.Name(),
+		"error", providerErr.Err.Error(),
+		"status_code", providerErr.StatusCode,
+		"latency", providerErr.Latency,
+	)
+}
+
+// logResult will log the provider that was used (and how many failed before it)
+func (c *Client) logResult(ctx context.Context, operation string, provider RateProvider,
+	currency Currency, failed int, latency time.Duration) {
+	if failed == 0 {
+		c.logger.DebugContext(ctx, operation+" returned from provider",
+			"provider", provider.Name(),
+			"currency", currency.Name(),
+			"latency", latency,
+		)
+		return
+	}
+	c.logger.InfoContext(ctx, operation+" returned from fallback provider",
+		"provider", provider.Name(),
+		"currency", currency.Name(),
+		"failed", failed,
+		"latency", latency,
+	)
+}
+
+// logAllFailed will log that every provider failed
+func (c *Client) logAllFailed(ctx context.Context, operation string, currency Currency, errs ProviderErrors) {
+	c.logger.ErrorContext(ctx, "all "+operation+" providers failed",
+		"currency", currency.Name(),
+		"failed", len(errs),
+		"error", errs.Error(),
+	)
+}
+
+// logCache will log a rate served from the cache
+func (c *Client) logCache(ctx context.Context, provider RateProvider, currency Currency, status CacheStatus) {
+	c.logger.DebugContext(ctx, "rate served from cache",
+		"provider", provider.Name(),
+		"currency", currency.Name(),
+		"cache", status.Name(),
+	)
+}
+
+// logHealthCheck will log a failed provider health check
+func (c *Client) logHealthCheck(ctx context.Context, health *ProviderHealth) {
+	c.logger.WarnContext(ctx, "provider health check failed",
+		"provider", health.Name,
+		"status", health.Status.Name(),
+		"error", health.Error,
+		"latency", health.Latency,
+	)
+}
+
+// logger will return the logger for the Coin Paprika client (defaults to discarding everything)
+func (p *PaprikaClient) logger() Logger {
+	if p.Logger == nil {
+		return nopLogger{}
+	}
+	return p.Logger
+}
+
+// logResponseError will log a failed Coin Paprika request (decoding errors are logged as errors)
+func (p *PaprikaClient) logResponseError(ctx context.Context, request *lastRequest, err error) {
+	logRequestError(ctx, p.logger(), "coin paprika", request, err)
+}
+
+// logger will return the logger for the CoinGecko client (defaults to discarding everything)
+func (g *GeckoClient) logger() Logger {
+	if g.Logger == nil {
+		return nopLogger{}
+	}
+	return g.Logger
+}
+
+// logResponseError will log a failed CoinGecko request (decoding errors are logged as errors)
+func (g *GeckoClient) logResponseError(ctx context.Context, request *lastRequest, err error) {
+	logRequestError(ctx, g.logger(), "coin gecko", request, err)
+}
+
+// logRequestError will log a failed request to the service (decoding errors are logged as errors)
+func logRequestError(ctx context.Context, logger Logger, service string, request *lastRequest, err error) {
+	args := []interface{}{
+		"method", request.Method,
+		"url", request.URL,
+		"status_code", request.StatusCode,
+		"error", err.Error(),
+	}
+	switch {
+	case errors.Is(err, ErrDecode):
+		logger.ErrorContext(ctx, "failed to decode "+service+" response", args...)
+	default:
+		logger.WarnContext(ctx, service+" request failed", args...)
+	}
+}
+
+// logger will return the logger for the Bitfinex client (defaults to discarding everything)
+func (b *BitfinexClient) logger() Logger {
+	if b.Logger == nil {
+		return nopLogger{}
+	}
+	return b.Logger
+}
+
+// logResponseError will log a failed Bitfinex request (decoding errors are logged as errors)
+func (b *BitfinexClient) logResponseError(ctx context.Context, request *lastRequest, err error) {
+	logRequestError(ctx, b.logger(), "bitfinex", request, err)
+}
@@ -0,0 +1,136 @@
+package bsvrates
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClient_Logger will test the events logged by the client
+func TestClient_Logger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate - attempt, failure and fallback", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		attempt := logger.find("debug", "requesting rate from provider")
+		if assert.NotNil(t, attempt) {
+			assert.Equal(t, "CoinPaprika", attempt.value("provider"))
+			assert.Equal(t, "usd", attempt.value("currency"))
+			assert.Equal(t, 1, attempt.value("attempt"))
+		}
+
+		failure := logger.find("warn", "rate provider failed, falling back to the next provider")
+		if assert.NotNil(t, failure) {
+			assert.Equal(t, "CoinPaprika", failure.value("provider"))
+			assert.Equal(t, http.StatusTooManyRequests, failure.value("status_code"))
+		}
+
+		fallback := logger.find("info", "rate returned from fallback provider")
+		if assert.NotNil(t, fallback) {
+			assert.Equal(t, "WhatsOnChain", fallback.value("provider"))
+			assert.Equal(t, 1, fallback.value("failed"))
+		}
+	})
+
+	t.Run("rate - all providers failed", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{}, ProviderCoinPaprika)
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.NotNil(t, logger.find("warn", "rate provider failed"))
+		assert.NotNil(t, logger.find("error", "all rate providers failed"))
+	})
+
+	t.Run("conversion - success", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, logger.find("debug", "requesting conversion from provider"))
+		assert.NotNil(t, logger.find("debug", "conversion returned from provider"))
+		assert.Nil(t, logger.find("warn", "conversion provider failed"))
+	})
+
+	t.Run("conversion - all providers failed", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.Logger = logger
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{}, ProviderCoinPaprika)
+
+		_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.Error(t, err)
+		assert.NotNil(t, logger.find("error", "all conversion providers failed"))
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		logger := &mockLogger{}
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		options.Logger = logger
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		for i := 0; i < 2; i++ {
+			_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+		}
+
+		hit := logger.find("debug", "rate served from cache")
+		if assert.NotNil(t, hit) {
+			assert.Equal(t, "hit", hit.value("cache"))
+		}
+	})
+}
+
+// TestPaprikaClient_Logger will test the events logged by the Coin Paprika client
+func TestPaprikaClient_Logger(t *testing.T) {
+	t.Parallel()
+
+	logger := &mockLogger{}
+	options := DefaultClientOptions()
+	options.Logger = logger
+	client := createPaprikaClient(options, &mockHTTPPaprika{})
+
+	t.Run("bad response", func(t *testing.T) {
+		_, err := client.GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 429)
+		assert.Error(t, err)
+
+		entry := logger.find("warn", "coin paprika request failed")
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, http.StatusTooManyRequests, entry.value("status_code"))
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		_, err := client.GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 503)
+		assert.Error(t, err)
+
+		entry := logger.find("error", "failed to decode coin paprika response")
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, http.StatusOK, entry.value("status_code"))
+		}
+	})
+
+	t.Run("no logger", func(t *testing.T) {
+		paprika := &PaprikaClient{HTTPClient: &mockHTTPPaprika{}}
+		assert.NotPanics(t, func() {
+			_, err := paprika.GetPriceConversion(context.Background(), USDCurrencyID, CoinPaprikaQuoteID, 503)
+			assert.Error(t, err)
+		})
+	})
+}
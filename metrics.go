@@ -0,0 +1,47 @@
+package bsvrates
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics is the interface for recording metrics about provider requests, fallbacks and the cache.
+// The request status code is the HTTP status (200 for a successful built-in provider) or 0 if unknown
+// (custom providers that succeed, or failures without a response).
+// See the prometheus package for an adapter that exposes them in the Prometheus text format
+type Metrics interface {
+	ObserveCache(provider string, currency Currency, status CacheStatus)
+	ObserveFallback(provider string, currency Currency)
+	ObserveProviderRequest(provider string, currency Currency, statusCode int, err error, latency time.Duration)
+}
+
+// nopMetrics is the default Metrics (discards everything)
+type nopMetrics struct{}
+
+// ObserveCache discards the cache result
+func (nopMetrics) ObserveCache(string, Currency, CacheStatus) {}
+
+// ObserveFallback discards the fallback
+func (nopMetrics) ObserveFallback(string, Currency) {}
+
+// ObserveProviderRequest discards the provider request
+func (nopMetrics) ObserveProviderRequest(string, Currency, int, error, time.Duration) {}
+
+// metrics will return the configured metrics (defaults to discarding everything)
+func (c *ClientOptions) metrics() Metrics {
+	if c.Metrics == nil {
+		return nopMetrics{}
+	}
+	return c.Metrics
+}
+
+// providerFailed will record the provider failure (logging it, and the fallback if there is one)
+func (c *Client) providerFailed(ctx context.Context, operation string, provider RateProvider, currency Currency,
+	err error, latency time.Duration, fallback bool) *ProviderError {
+	providerErr := newProviderError(provider, err, latency)
+	c.logFailure(ctx, operation, providerErr, currency, fallback)
+	if fallback {
+		c.metrics.ObserveFallback(provider.Name(), currency)
+	}
+	return providerErr
+}
@@ -0,0 +1,83 @@
+package bsvrates
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClient_Metrics will test the metrics recorded by the client
+func TestClient_Metrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate - requests and fallback", func(t *testing.T) {
+		metrics := newMockMetrics()
+		options := DefaultClientOptions()
+		options.Metrics = metrics
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		if assert.Equal(t, 2, len(metrics.requests)) {
+			assert.Equal(t, "CoinPaprika", metrics.requests[0].provider)
+			assert.Equal(t, http.StatusTooManyRequests, metrics.requests[0].statusCode)
+			assert.Error(t, metrics.requests[0].err)
+			assert.Equal(t, "WhatsOnChain", metrics.requests[1].provider)
+			assert.Equal(t, http.StatusOK, metrics.requests[1].statusCode)
+			assert.NoError(t, metrics.requests[1].err)
+		}
+		assert.Equal(t, []string{"CoinPaprika"}, metrics.fallbacks)
+		assert.Empty(t, metrics.cache)
+	})
+
+	t.Run("conversion - requests", func(t *testing.T) {
+		metrics := newMockMetrics()
+		options := DefaultClientOptions()
+		options.Metrics = metrics
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+
+		if assert.Equal(t, 1, len(metrics.requests)) {
+			assert.Equal(t, "CoinPaprika", metrics.requests[0].provider)
+			assert.Equal(t, Currency(CurrencyDollars), metrics.requests[0].currency)
+		}
+		assert.Empty(t, metrics.fallbacks)
+	})
+
+	t.Run("custom provider - no status code", func(t *testing.T) {
+		metrics := newMockMetrics()
+		options := newMockOptions(&mockRateProvider{name: "in-house", rate: 150})
+		options.Metrics = metrics
+		client := NewClient(options, nil)
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		if assert.Equal(t, 1, len(metrics.requests)) {
+			assert.Equal(t, 0, metrics.requests[0].statusCode)
+		}
+	})
+
+	t.Run("cache - miss then hit", func(t *testing.T) {
+		metrics := newMockMetrics()
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		options.Metrics = metrics
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		for i := 0; i < 2; i++ {
+			_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, metrics.cache[CacheMiss])
+		assert.Equal(t, 1, metrics.cache[CacheHit])
+		assert.Equal(t, 1, len(metrics.requests))
+	})
+}
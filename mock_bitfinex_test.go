@@ -0,0 +1,35 @@
+package bsvrates
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// newMockBitfinexServer returns a local stub server for the Bitfinex api
+func newMockBitfinexServer() *httptest.Server {
+	mux := http.NewServeMux()
+
+	// Ticker (malformed for tBSVEUR, unknown symbols return an error)
+	mux.HandleFunc("/ticker/", func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ticker/tBSVUSD":
+			_, _ = w.Write([]byte(`[48.05,1520.3,48.11,980.75,-0.42,-0.0087,48.09,35120.6,49.2,47.51]`))
+		case "/ticker/tBSVEUR":
+			_, _ = w.Write([]byte(`[44.2,310.5]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`["error",10020,"symbol: invalid"]`))
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// newMockBitfinexClient returns a Bitfinex client for the local stub server
+func newMockBitfinexClient(server *httptest.Server) *BitfinexClient {
+	return &BitfinexClient{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		UserAgent:  defaultUserAgent,
+	}
+}
@@ -0,0 +1,52 @@
+package bsvrates
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// newMockGeckoServer returns a local stub server for the CoinGecko api
+func newMockGeckoServer() *httptest.Server {
+	mux := http.NewServeMux()
+
+	// Ping
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!"}`))
+	})
+
+	// Simple price (rate limited for krw, malformed for jpy, unknown coins return nothing)
+	mux.HandleFunc("/simple/price", func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Query().Get("ids") != CoinGeckoCoinID:
+			_, _ = w.Write([]byte(`{}`))
+		case req.URL.Query().Get("vs_currencies") == "krw":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case req.URL.Query().Get("vs_currencies") == "jpy":
+			_, _ = w.Write([]byte(`{"bitcoin-cash-sv":`))
+		default:
+			_, _ = w.Write([]byte(`{"bitcoin-cash-sv":{"usd":48.12,"usd_24h_vol":21498730.95,"eur":44.31,"eur_24h_vol":19798412.22,"last_updated_at":1700000000}}`))
+		}
+	})
+
+	// Market chart range
+	mux.HandleFunc("/coins/"+CoinGeckoCoinID+"/market_chart/range", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"prices":[[1609462861000,162.61],[1609466461000,163.02]],"market_caps":[[1609462861000,3016291856.12],[1609466461000,3023914220.45]],"total_volumes":[[1609462861000,501124880.5],[1609466461000,498003187.4]]}`))
+	})
+
+	// OHLC
+	mux.HandleFunc("/coins/"+CoinGeckoCoinID+"/ohlc", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[[1609462800000,162.1,163.5,161.8,162.6],[1609464600000,162.6,163.9,162.2,163.0]]`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// newMockGeckoClient returns a CoinGecko client for the local stub server
+func newMockGeckoClient(server *httptest.Server) *GeckoClient {
+	return &GeckoClient{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		UserAgent:  defaultUserAgent,
+	}
+}
@@ -20,6 +20,11 @@ func (m *mockPaprikaBase) GetBaseAmountAndCurrencyID(currency string, _ float64)
 	return currency, 0.01
 }
 
+// GetGlobal is a mock response
+func (m *mockPaprikaBase) GetGlobal(context.Context) (response *GlobalResponse, err error) {
+	return
+}
+
 // GetPriceConversion is a mock response
 func (m *mockPaprikaBase) GetPriceConversion(context.Context, string, string, float64) (response *PriceConversionResponse, err error) {
 	return
@@ -72,6 +77,32 @@ func (m *mockPaprikaValid) GetMarketPrice(_ context.Context, coinID string) (res
 // GetPriceConversion is a mock response
 func (m *mockPaprikaValid) GetPriceConversion(_ context.Context, baseCurrencyID, quoteCurrencyID string, amount float64) (response *PriceConversionResponse, err error) {
 
+	// Currency -> Currency (fiat rate)
+	if baseCurrencyID != CoinPaprikaQuoteID && quoteCurrencyID != CoinPaprikaQuoteID {
+		response = &PriceConversionResponse{
+			Amount:          amount,
+			BaseCurrencyID:  baseCurrencyID,
+			Price:           1.1 * amount,
+			QuoteCurrencyID: quoteCurrencyID,
+		}
+		return
+	}
+
+	// BSV -> Currency (rate)
+	if baseCurrencyID == CoinPaprikaQuoteID {
+		response = &PriceConversionResponse{
+			Amount:                amount,
+			BaseCurrencyID:        baseCurrencyID,
+			BaseCurrencyName:      "Bitcoin SV",
+			BasePriceLastUpdated:  "2020-07-01T22:03:14Z",
+			Price:                 145.12,
+			QuoteCurrencyID:       quoteCurrencyID,
+			QuoteCurrencyName:     "Euro",
+			QuotePriceLastUpdated: "2020-07-01T22:03:14Z",
+		}
+		return
+	}
+
 	response = &PriceConversionResponse{
 		Amount:                amount,
 		BaseCurrencyID:        baseCurrencyID,
@@ -117,3 +148,29 @@ func (m *mockPaprikaFailed) GetHistoricalTickers(_ context.Context, _ string, _,
 func (m *mockPaprikaFailed) IsAcceptedCurrency(_ string) bool {
 	return false
 }
+
+// mockPaprikaRateLimited for mocking requests (429 with the last request)
+type mockPaprikaRateLimited struct {
+	mockPaprikaBase
+}
+
+// GetMarketPrice is a mock response
+func (m *mockPaprikaRateLimited) GetMarketPrice(_ context.Context, _ string) (response *TickerResponse, err error) {
+	response = &TickerResponse{LastRequest: &lastRequest{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusTooManyRequests,
+	}}
+	err = &ProviderHTTPError{Method: http.MethodGet, StatusCode: http.StatusTooManyRequests}
+	return
+}
+
+// GetPriceConversion is a mock response
+func (m *mockPaprikaRateLimited) GetPriceConversion(context.Context, string, string,
+	float64) (response *PriceConversionResponse, err error) {
+	response = &PriceConversionResponse{LastRequest: &lastRequest{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusTooManyRequests,
+	}}
+	err = &ProviderHTTPError{Method: http.MethodGet, StatusCode: http.StatusTooManyRequests}
+	return
+}
@@ -0,0 +1,68 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+)
+
+// mockLogEntry is a message recorded by the mockLogger
+type mockLogEntry struct {
+	args  []interface{}
+	level string
+	msg   string
+}
+
+// mockLogger records every message
+type mockLogger struct {
+	entries []mockLogEntry
+	mu      sync.Mutex
+}
+
+// DebugContext records the message
+func (m *mockLogger) DebugContext(_ context.Context, msg string, args ...interface{}) {
+	m.record("debug", msg, args)
+}
+
+// ErrorContext records the message
+func (m *mockLogger) ErrorContext(_ context.Context, msg string, args ...interface{}) {
+	m.record("error", msg, args)
+}
+
+// InfoContext records the message
+func (m *mockLogger) InfoContext(_ context.Context, msg string, args ...interface{}) {
+	m.record("info", msg, args)
+}
+
+// WarnContext records the message
+func (m *mockLogger) WarnContext(_ context.Context, msg string, args ...interface{}) {
+	m.record("warn", msg, args)
+}
+
+// record will store the message
+func (m *mockLogger) record(level, msg string, args []interface{}) {
+	m.mu.Lock()
+	m.entries = append(m.entries, mockLogEntry{args: args, level: level, msg: msg})
+	m.mu.Unlock()
+}
+
+// find will return the first entry with the level and message (nil if not found)
+func (m *mockLogger) find(level, msg string) *mockLogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for index := range m.entries {
+		if m.entries[index].level == level && m.entries[index].msg == msg {
+			return &m.entries[index]
+		}
+	}
+	return nil
+}
+
+// value will return the value for the key in the entry args (nil if not found)
+func (e *mockLogEntry) value(key string) interface{} {
+	for index := 0; index+1 < len(e.args); index += 2 {
+		if e.args[index] == key {
+			return e.args[index+1]
+		}
+	}
+	return nil
+}
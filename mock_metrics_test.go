@@ -0,0 +1,51 @@
+package bsvrates
+
+import (
+	"sync"
+	"time"
+)
+
+// mockRequestMetric is a provider request recorded by the mockMetrics
+type mockRequestMetric struct {
+	currency   Currency
+	err        error
+	provider   string
+	statusCode int
+}
+
+// mockMetrics records every observation
+type mockMetrics struct {
+	cache     map[CacheStatus]int
+	fallbacks []string
+	mu        sync.Mutex
+	requests  []mockRequestMetric
+}
+
+// newMockMetrics returns a new mockMetrics
+func newMockMetrics() *mockMetrics {
+	return &mockMetrics{cache: make(map[CacheStatus]int)}
+}
+
+// ObserveCache records the cache status
+func (m *mockMetrics) ObserveCache(_ string, _ Currency, status CacheStatus) {
+	m.mu.Lock()
+	m.cache[status]++
+	m.mu.Unlock()
+}
+
+// ObserveFallback records the provider
+func (m *mockMetrics) ObserveFallback(provider string, _ Currency) {
+	m.mu.Lock()
+	m.fallbacks = append(m.fallbacks, provider)
+	m.mu.Unlock()
+}
+
+// ObserveProviderRequest records the request
+func (m *mockMetrics) ObserveProviderRequest(provider string, currency Currency, statusCode int,
+	err error, _ time.Duration) {
+	m.mu.Lock()
+	m.requests = append(m.requests, mockRequestMetric{
+		currency: currency, err: err, provider: provider, statusCode: statusCode,
+	})
+	m.mu.Unlock()
+}
@@ -0,0 +1,133 @@
+package bsvrates
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// mockRateProvider is a custom provider for mocking requests
+type mockRateProvider struct {
+	calls      int64      // Number of rate requests (use atomic)
+	currencies []Currency // Supported currencies (all if empty)
+	err        error      // Error to return from GetRate (instead of a 502)
+	name       string
+	rate       float64
+	satoshis   int64
+}
+
+// SupportsCurrency is a mock response
+func (m *mockRateProvider) SupportsCurrency(currency Currency) bool {
+	if len(m.currencies) == 0 {
+		return true
+	}
+	for _, supported := range m.currencies {
+		if supported == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// Name is a mock response
+func (m *mockRateProvider) Name() string {
+	return m.name
+}
+
+// GetRate is a mock response
+func (m *mockRateProvider) GetRate(_ context.Context, _ Currency) (float64, error) {
+	atomic.AddInt64(&m.calls, 1)
+	if m.err != nil {
+		return 0, m.err
+	} else if m.rate <= 0 {
+		return 0, fmt.Errorf("request to %s fails... 502", m.name)
+	}
+	return m.rate, nil
+}
+
+// GetConversion is a mock response
+func (m *mockRateProvider) GetConversion(_ context.Context, _ Currency, _ float64) (int64, error) {
+	if m.satoshis <= 0 {
+		return 0, fmt.Errorf("request to %s fails... 502", m.name)
+	}
+	return m.satoshis, nil
+}
+
+// rateCalls will return the number of rate requests
+func (m *mockRateProvider) rateCalls() int64 {
+	return atomic.LoadInt64(&m.calls)
+}
+
+// mockVolumeProvider is a custom provider that also reports the 24 hour volume
+type mockVolumeProvider struct {
+	mockRateProvider
+	volume float64 // 24 hour volume in the currency
+}
+
+// GetQuote is a mock response
+func (m *mockVolumeProvider) GetQuote(ctx context.Context, currency Currency) (*Quote, error) {
+	rate, err := m.GetRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{Rate: rate, Volume24h: m.volume}, nil
+}
+
+// mockSlowProvider is a custom provider that blocks until released (or the context is done)
+type mockSlowProvider struct {
+	calls     int64         // Number of rate requests (use atomic)
+	cancelled int64         // Number of requests that saw the context cancelled (use atomic)
+	hang      bool          // Ignore the context (only return once released)
+	release   chan struct{} // Close to release all requests
+}
+
+// newMockSlowProvider returns a new slow provider
+func newMockSlowProvider() *mockSlowProvider {
+	return &mockSlowProvider{release: make(chan struct{})}
+}
+
+// Name is a mock response
+func (m *mockSlowProvider) Name() string {
+	return "slow"
+}
+
+// SupportsCurrency is a mock response
+func (m *mockSlowProvider) SupportsCurrency(_ Currency) bool {
+	return true
+}
+
+// GetRate is a mock response
+func (m *mockSlowProvider) GetRate(ctx context.Context, _ Currency) (float64, error) {
+	atomic.AddInt64(&m.calls, 1)
+	if m.hang {
+		<-m.release
+		return 150, nil
+	}
+	select {
+	case <-m.release:
+		return 150, nil
+	case <-ctx.Done():
+		atomic.AddInt64(&m.cancelled, 1)
+		return 0, ctx.Err()
+	}
+}
+
+// GetConversion is a mock response
+func (m *mockSlowProvider) GetConversion(ctx context.Context, currency Currency, amount float64) (int64, error) {
+	rate, err := m.GetRate(ctx, currency)
+	if err != nil {
+		return 0, err
+	}
+	return ConvertPriceToSatoshis(rate, amount)
+}
+
+// mockCheckedProvider is a custom provider with a health check
+type mockCheckedProvider struct {
+	mockRateProvider
+	healthErr error // Error to return from HealthCheck
+}
+
+// HealthCheck is a mock response
+func (m *mockCheckedProvider) HealthCheck(context.Context) error {
+	return m.healthErr
+}
@@ -0,0 +1,66 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+)
+
+// mockSpanKey is the context key for the current mockSpan
+type mockSpanKey struct{}
+
+// mockSpan records the attributes and error of a span
+type mockSpan struct {
+	attributes map[string]interface{}
+	ended      bool
+	err        error
+	name       string
+	parent     *mockSpan
+	tracer     *mockTracer
+}
+
+// End records the error
+func (s *mockSpan) End(err error) {
+	s.tracer.mu.Lock()
+	s.ended, s.err = true, err
+	s.tracer.mu.Unlock()
+}
+
+// SetAttributes records the attributes
+func (s *mockSpan) SetAttributes(attributes ...Attribute) {
+	s.tracer.mu.Lock()
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+	s.tracer.mu.Unlock()
+}
+
+// mockTracer records every span
+type mockTracer struct {
+	mu    sync.Mutex
+	spans []*mockSpan
+}
+
+// Start records a new span (the parent is the span in the context)
+func (m *mockTracer) Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(mockSpanKey{}).(*mockSpan)
+	span := &mockSpan{attributes: make(map[string]interface{}), name: name, parent: parent, tracer: m}
+	for _, attribute := range attributes {
+		span.attributes[attribute.Key] = attribute.Value
+	}
+	m.mu.Lock()
+	m.spans = append(m.spans, span)
+	m.mu.Unlock()
+	return context.WithValue(ctx, mockSpanKey{}, span), span
+}
+
+// named will return the spans with the name (in start order)
+func (m *mockTracer) named(name string) (spans []*mockSpan) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, span := range m.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return
+}
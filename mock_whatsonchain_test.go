@@ -53,3 +53,13 @@ func (m *mockWOCFailed) GetExchangeRate(_ context.Context) (rate *whatsonchain.E
 
 	return nil, errors.New("some error occurred")
 }
+
+// mockWOCDown for mocking requests (every request fails)
+type mockWOCDown struct {
+	mockWOCFailed
+}
+
+// GetChainInfo is a mock response
+func (m *mockWOCDown) GetChainInfo(context.Context) (chainInfo *whatsonchain.ChainInfo, err error) {
+	return nil, errors.New("service unavailable")
+}
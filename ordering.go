@@ -0,0 +1,122 @@
+package bsvrates
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ewmaAlpha is the weight of the latest request in the provider scores (EWMA)
+const ewmaAlpha = 0.2
+
+// ewmaFailureLatency is the minimum latency of a failed request in the provider scores
+// (so a fast failure does not look like a fast response)
+const ewmaFailureLatency = 5 * time.Second
+
+// ewmaHalfLife is how long it takes an idle provider score to move halfway back to the neutral score
+const ewmaHalfLife = time.Minute
+
+// ewmaNeutralLatency is the latency of a provider without recent requests (a neutral score of 0.5)
+const ewmaNeutralLatency = time.Second
+
+// ProviderOrdering is the order in which providers are tried (see ClientOptions.ProviderOrdering)
+type ProviderOrdering uint8
+
+// ProviderOrdering constants for the different ways to order providers.
+// Leave the start and last constants in place
+const (
+	OrderingPinned ProviderOrdering = iota // 0 (the order the providers were registered in)
+
+	OrderingAdaptive // 1 (the providers with the best recent success rate and latency are tried first)
+	orderingLast     // 2
+)
+
+// Name will return the display name for the given provider ordering
+func (o ProviderOrdering) Name() string {
+	switch o {
+	case OrderingPinned:
+		return "pinned"
+	case OrderingAdaptive:
+		return "adaptive"
+	case orderingLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// score will return the adaptive score of the provider (higher is better).
+// The score is the EWMA success rate divided by (1 + the EWMA latency in seconds).
+// Providers without requests have a neutral score of 0.5, and idle scores decay back towards it
+// (so a demoted provider is tried again once the others do worse, but an idle one never jumps ahead)
+func (s *providerStats) score() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	success, latency := s.decayed()
+	return success / (1 + latency.Seconds())
+}
+
+// decayed will return the EWMA success rate and latency, moved towards the neutral values
+// (success rate of 1, ewmaNeutralLatency) by the time since the last request
+func (s *providerStats) decayed() (success float64, latency time.Duration) {
+	if !s.scored {
+		return 1, ewmaNeutralLatency
+	}
+	weight := math.Pow(0.5, float64(time.Since(s.observedAt))/float64(ewmaHalfLife))
+	success = 1 + (s.ewmaSuccess-1)*weight
+	latency = ewmaNeutralLatency + time.Duration(float64(s.ewmaLatency-ewmaNeutralLatency)*weight)
+	return
+}
+
+// observe will add the request to the EWMA success rate and latency
+// (a failed request counts as at least ewmaFailureLatency)
+func (s *providerStats) observe(err error, latency time.Duration) {
+	success := 0.0
+	if err == nil {
+		success = 1
+	} else if latency < ewmaFailureLatency {
+		latency = ewmaFailureLatency
+	}
+	prevSuccess, prevLatency := s.decayed()
+	s.ewmaSuccess = ewmaAlpha*success + (1-ewmaAlpha)*prevSuccess
+	s.ewmaLatency = time.Duration(ewmaAlpha*float64(latency) + (1-ewmaAlpha)*float64(prevLatency))
+	s.observedAt, s.scored = time.Now(), true
+}
+
+// orderProviders will return the providers in the order they should be tried
+// (sorted by score if ClientOptions.ProviderOrdering is adaptive, ties keep the registration order)
+func (c *Client) orderProviders(providers []RateProvider) []RateProvider {
+	if c.options.ProviderOrdering != OrderingAdaptive || len(providers) < 2 {
+		return providers
+	}
+
+	// Score the providers
+	type scoredProvider struct {
+		provider RateProvider
+		score    float64
+	}
+	scored := make([]scoredProvider, 0, len(providers))
+	for _, provider := range providers {
+		scored = append(scored, scoredProvider{provider: provider, score: c.health.get(provider.Name()).score()})
+	}
+
+	// Best score first
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	ordered := make([]RateProvider, 0, len(scored))
+	for _, entry := range scored {
+		ordered = append(ordered, entry.provider)
+	}
+	return ordered
+}
+
+// ProviderScores will return the adaptive score of each provider (by name, higher is better).
+// The scores are kept for every ordering, but only used if ClientOptions.ProviderOrdering is adaptive
+func (c *Client) ProviderScores() map[string]float64 {
+	scores := make(map[string]float64)
+	for _, provider := range c.RateProviders() {
+		scores[provider.Name()] = c.health.get(provider.Name()).score()
+	}
+	return scores
+}
@@ -0,0 +1,227 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProviderOrdering_Name will test the method Name()
+func TestProviderOrdering_Name(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		ordering     ProviderOrdering
+		expectedName string
+	}{
+		{OrderingPinned, "pinned"},
+		{OrderingAdaptive, "adaptive"},
+		{orderingLast, ""},
+		{123, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedName, test.ordering.Name())
+	}
+}
+
+// TestProviderStats_Score will test the methods observe() and score()
+func TestProviderStats_Score(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no requests", func(t *testing.T) {
+		assert.Equal(t, 0.5, new(providerStats).score())
+	})
+
+	t.Run("first request starts from the neutral score", func(t *testing.T) {
+		stats := new(providerStats)
+		stats.observe(nil, time.Second)
+		assert.InDelta(t, 0.5, stats.score(), 0.001)
+
+		stats = new(providerStats)
+		stats.observe(nil, 0)
+		assert.InDelta(t, 0.8, stats.ewmaLatency.Seconds(), 0.001)
+		assert.InDelta(t, 0.5556, stats.score(), 0.001)
+	})
+
+	t.Run("failure lowers the score below a slow success", func(t *testing.T) {
+		stats := new(providerStats)
+		stats.observe(errors.New("failed"), 0)
+		assert.InDelta(t, 0.8, stats.ewmaSuccess, 0.001)
+		assert.InDelta(t, 1.8, stats.ewmaLatency.Seconds(), 0.001)
+		assert.InDelta(t, 0.2857, stats.score(), 0.001)
+		assert.Less(t, stats.score(), 0.5)
+
+		stats.observe(errors.New("failed"), 0)
+		assert.InDelta(t, 0.64/(1+2.44), stats.score(), 0.001)
+	})
+
+	t.Run("failure latency is counted", func(t *testing.T) {
+		stats := new(providerStats)
+		stats.observe(errors.New("timeout"), 10*time.Second)
+		assert.InDelta(t, 2.8, stats.ewmaLatency.Seconds(), 0.001)
+		assert.InDelta(t, 0.8/3.8, stats.score(), 0.001)
+	})
+
+	t.Run("latency lowers the score", func(t *testing.T) {
+		stats := new(providerStats)
+		stats.observe(nil, 0)
+		stats.observe(nil, 5*time.Second)
+		assert.InDelta(t, 1.64, stats.ewmaLatency.Seconds(), 0.001)
+		assert.InDelta(t, 1/2.64, stats.score(), 0.001)
+	})
+
+	t.Run("idle scores decay towards the neutral score", func(t *testing.T) {
+		stats := new(providerStats)
+		stats.observe(errors.New("failed"), 0)
+		stats.observedAt = time.Now().Add(-ewmaHalfLife)
+		assert.InDelta(t, 0.9/2.4, stats.score(), 0.001)
+		stats.observedAt = time.Now().Add(-100 * ewmaHalfLife)
+		assert.InDelta(t, 0.5, stats.score(), 0.001)
+
+		healthy := new(providerStats)
+		for i := 0; i < 10; i++ {
+			healthy.observe(nil, 0)
+		}
+		healthy.observedAt = time.Now().Add(-100 * ewmaHalfLife)
+		assert.InDelta(t, 0.5, healthy.score(), 0.001)
+	})
+}
+
+// TestClient_OrderProviders will test the method orderProviders()
+func TestClient_OrderProviders(t *testing.T) {
+	t.Parallel()
+
+	first := &mockRateProvider{name: "first"}
+	second := &mockRateProvider{name: "second"}
+	third := &mockRateProvider{name: "third"}
+	providers := []RateProvider{first, second, third}
+
+	t.Run("pinned", func(t *testing.T) {
+		client := NewClient(&ClientOptions{}, nil).(*Client)
+		client.health.record("first", errors.New("failed"), 0)
+		assert.Equal(t, providers, client.orderProviders(providers))
+	})
+
+	t.Run("adaptive", func(t *testing.T) {
+		client := NewClient(&ClientOptions{ProviderOrdering: OrderingAdaptive}, nil).(*Client)
+
+		// No requests keeps the registration order
+		assert.Equal(t, providers, client.orderProviders(providers))
+
+		// Failures and latency move providers back
+		client.health.record("first", errors.New("failed"), 0)
+		client.health.record("second", nil, time.Second)
+		client.health.record("third", nil, 100*time.Millisecond)
+		assert.Equal(t, []RateProvider{third, second, first}, client.orderProviders(providers))
+
+		// The original slice is not changed
+		assert.Equal(t, []RateProvider{first, second, third}, providers)
+	})
+
+	t.Run("adaptive - idle scores", func(t *testing.T) {
+		client := NewClient(&ClientOptions{ProviderOrdering: OrderingAdaptive}, nil).(*Client)
+		for i := 0; i < 10; i++ {
+			client.health.record("first", nil, 100*time.Millisecond)
+		}
+		client.health.record("second", nil, 0)
+		client.health.record("third", errors.New("failed"), 0)
+		assert.Equal(t, []RateProvider{first, second, third}, client.orderProviders(providers))
+
+		// An idle fallback does not move ahead of a healthy primary
+		client.health.get("second").observedAt = time.Now().Add(-100 * ewmaHalfLife)
+		assert.Equal(t, []RateProvider{first, second, third}, client.orderProviders(providers))
+
+		// An idle failed provider is tried again before a slow provider
+		client.health.record("second", nil, 5*time.Second)
+		client.health.get("third").observedAt = time.Now().Add(-100 * ewmaHalfLife)
+		assert.Equal(t, []RateProvider{first, third, second}, client.orderProviders(providers))
+	})
+}
+
+// TestClient_ProviderOrdering will test the provider ordering for rates and conversions
+func TestClient_ProviderOrdering(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pinned - failing provider is always tried first", func(t *testing.T) {
+		flaky := &mockRateProvider{name: "flaky"}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{flaky, &mockRateProvider{name: "steady", rate: 150}}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 3; i++ {
+			result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+			assert.Equal(t, "steady", result.ProviderName)
+		}
+		assert.Equal(t, int64(3), flaky.rateCalls())
+	})
+
+	t.Run("adaptive - healthiest provider is tried first", func(t *testing.T) {
+		flaky := &mockRateProvider{name: "flaky"}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{flaky, &mockRateProvider{name: "steady", rate: 150}}
+		options.ProviderOrdering = OrderingAdaptive
+		client := NewClient(options, nil)
+
+		for i := 0; i < 3; i++ {
+			result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+			assert.NoError(t, err)
+			assert.Equal(t, "steady", result.ProviderName)
+		}
+		assert.Equal(t, int64(1), flaky.rateCalls())
+
+		scores := client.ProviderScores()
+		assert.InDelta(t, 0.2857, scores["flaky"], 0.001)
+		assert.Greater(t, scores["steady"], 0.5)
+
+		// The last result had no failures
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(result.Errors))
+	})
+
+	t.Run("adaptive - primary recovers after a failure", func(t *testing.T) {
+		primary := &mockRateProvider{name: "primary", err: errors.New("failed")}
+		options := newMockOptions(primary, &mockRateProvider{name: "backup", rate: 150})
+		options.ProviderOrdering = OrderingAdaptive
+		client := NewClient(options, nil).(*Client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "backup", result.ProviderName)
+
+		// The backup slows down and the idle primary decays back to the neutral score
+		primary.err, primary.rate = nil, 100
+		for i := 0; i < 2; i++ {
+			client.health.record("backup", nil, 5*time.Second)
+		}
+		client.health.get("primary").observedAt = time.Now().Add(-100 * ewmaHalfLife)
+		result, err = client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", result.ProviderName)
+		assert.Equal(t, int64(2), primary.rateCalls())
+		assert.Greater(t, client.ProviderScores()["primary"], client.ProviderScores()["backup"])
+	})
+
+	t.Run("adaptive - conversion", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{
+			&mockRateProvider{name: "flaky"},
+			&mockRateProvider{name: "steady", satoshis: 1000},
+		}
+		options.ProviderOrdering = OrderingAdaptive
+		client := NewClient(options, nil)
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result.Errors))
+
+		result, err = client.GetConversionDetails(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "steady", result.ProviderName)
+		assert.Equal(t, 0, len(result.Errors))
+	})
+}
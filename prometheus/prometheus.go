@@ -0,0 +1,202 @@
+/*
+Package prometheus exposes the go-bsvrates metrics in the Prometheus text format
+(without depending on the Prometheus client library)
+*/
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tonicpow/go-bsvrates"
+)
+
+// Metric names and label values
+const (
+	metricCacheLookups    = "bsvrates_cache_lookups_total"
+	metricFallbacks       = "bsvrates_provider_fallbacks_total"
+	metricRequestDuration = "bsvrates_provider_request_duration_seconds"
+	metricRequests        = "bsvrates_provider_requests_total"
+	resultError           = "error"
+	resultSuccess         = "success"
+	statusCodeUnknown     = "none"
+	textFormatContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// DefaultBuckets are the default latency buckets (in seconds, same as the Prometheus client)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram is a cumulative latency histogram
+type histogram struct {
+	count  uint64   // Number of observations
+	counts []uint64 // Observations per bucket (cumulative when written)
+	sum    float64  // Sum of the observations (seconds)
+}
+
+// Collector implements bsvrates.Metrics and writes the metrics in the Prometheus text format
+type Collector struct {
+	buckets   []float64             // Latency buckets (seconds)
+	cache     map[string]uint64     // Cache lookups by labels
+	fallbacks map[string]uint64     // Fallbacks by labels
+	latency   map[string]*histogram // Request latency by labels
+	mu        sync.Mutex            // Guards the metrics
+	requests  map[string]uint64     // Requests by labels
+}
+
+// NewCollector will return a new collector (uses DefaultBuckets if no buckets are given)
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Collector{
+		buckets:   sorted,
+		cache:     make(map[string]uint64),
+		fallbacks: make(map[string]uint64),
+		latency:   make(map[string]*histogram),
+		requests:  make(map[string]uint64),
+	}
+}
+
+// ObserveCache will count the cache lookup
+func (c *Collector) ObserveCache(provider string, currency bsvrates.Currency, status bsvrates.CacheStatus) {
+	key := labels("provider", provider, "currency", currency.Name(), "status", status.Name())
+	c.mu.Lock()
+	c.cache[key]++
+	c.mu.Unlock()
+}
+
+// ObserveFallback will count the fallback from the provider to the next provider
+func (c *Collector) ObserveFallback(provider string, currency bsvrates.Currency) {
+	key := labels("provider", provider, "currency", currency.Name())
+	c.mu.Lock()
+	c.fallbacks[key]++
+	c.mu.Unlock()
+}
+
+// ObserveProviderRequest will count the request (by result and status code) and observe the latency
+func (c *Collector) ObserveProviderRequest(provider string, currency bsvrates.Currency, statusCode int,
+	err error, latency time.Duration) {
+
+	// Request labels
+	result, status := resultSuccess, statusCodeUnknown
+	if err != nil {
+		result = resultError
+	}
+	if statusCode > 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	requestKey := labels(
+		"provider", provider, "currency", currency.Name(), "result", result, "status_code", status,
+	)
+	latencyKey := labels("provider", provider)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Count the request
+	c.requests[requestKey]++
+
+	// Observe the latency
+	h, ok := c.latency[latencyKey]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.latency[latencyKey] = h
+	}
+	seconds := latency.Seconds()
+	h.count++
+	h.sum += seconds
+	for index, bound := range c.buckets {
+		if seconds <= bound {
+			h.counts[index]++
+			break
+		}
+	}
+}
+
+// WriteTo will write all the metrics in the Prometheus text format
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+
+	c.mu.Lock()
+	writeCounters(buf, metricRequests, "Requests to the rate providers.", c.requests)
+	writeHistograms(buf, metricRequestDuration, "Latency of the requests to the rate providers.", c.buckets, c.latency)
+	writeCounters(buf, metricFallbacks, "Fallbacks from a failed provider to the next provider.", c.fallbacks)
+	writeCounters(buf, metricCacheLookups, "Rate cache lookups by status.", c.cache)
+	c.mu.Unlock()
+
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP will serve the metrics in the Prometheus text format (IE: http.Handle("/metrics", collector))
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", textFormatContentType)
+	_, _ = c.WriteTo(w)
+}
+
+// writeCounters will write the counter metric (skipped if there are no values)
+func writeCounters(buf *bytes.Buffer, name, help string, values map[string]uint64) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(buf, "%s{%s} %d\n", name, key, values[key])
+	}
+}
+
+// writeHistograms will write the histogram metric (skipped if there are no values)
+func writeHistograms(buf *bytes.Buffer, name, help string, buckets []float64, values map[string]*histogram) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := values[key]
+		var cumulative uint64
+		for index, bound := range buckets {
+			cumulative += h.counts[index]
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"%s\"} %d\n",
+				name, key, strconv.FormatFloat(bound, 'g', -1, 64), cumulative,
+			)
+		}
+		fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, key, h.count)
+		fmt.Fprintf(buf, "%s_sum{%s} %s\n", name, key, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", name, key, h.count)
+	}
+}
+
+// sortedKeys will return the keys of the counter in order
+func sortedKeys(values map[string]uint64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// labelEscaper escapes label values (backslash, double-quote and line feed)
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels will return the label pairs (IE: provider="CoinPaprika",currency="usd")
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for index := 0; index+1 < len(pairs); index += 2 {
+		parts = append(parts, pairs[index]+`="`+labelEscaper.Replace(pairs[index+1])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
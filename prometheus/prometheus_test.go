@@ -0,0 +1,161 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tonicpow/go-bsvrates"
+)
+
+// Collector must satisfy the metrics interface
+var _ bsvrates.Metrics = (*Collector)(nil)
+
+// mockProvider is a custom provider for testing
+type mockProvider struct {
+	name string
+	rate float64
+}
+
+// Name will return the name of the provider
+func (m *mockProvider) Name() string {
+	return m.name
+}
+
+// SupportsCurrency will return true for USD
+func (m *mockProvider) SupportsCurrency(currency bsvrates.Currency) bool {
+	return currency == bsvrates.CurrencyDollars
+}
+
+// GetRate will return the rate (or an error if not set)
+func (m *mockProvider) GetRate(context.Context, bsvrates.Currency) (float64, error) {
+	if m.rate <= 0 {
+		return 0, errors.New("provider failed")
+	}
+	return m.rate, nil
+}
+
+// GetConversion will return the conversion using the rate
+func (m *mockProvider) GetConversion(ctx context.Context, currency bsvrates.Currency, amount float64) (int64, error) {
+	rate, err := m.GetRate(ctx, currency)
+	if err != nil {
+		return 0, err
+	}
+	return bsvrates.ConvertPriceToSatoshis(rate, amount)
+}
+
+// TestCollector_WriteTo will test the method WriteTo()
+func TestCollector_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no metrics", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		n, err := NewCollector().WriteTo(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), n)
+	})
+
+	t.Run("all metrics", func(t *testing.T) {
+		collector := NewCollector(0.1, 0.01)
+		collector.ObserveProviderRequest("CoinPaprika", bsvrates.CurrencyDollars, http.StatusTooManyRequests,
+			errors.New("rate limited"), 5*time.Millisecond)
+		collector.ObserveProviderRequest("WhatsOnChain", bsvrates.CurrencyDollars, http.StatusOK, nil, 50*time.Millisecond)
+		collector.ObserveProviderRequest("WhatsOnChain", bsvrates.CurrencyDollars, http.StatusOK, nil, time.Second)
+		collector.ObserveFallback("CoinPaprika", bsvrates.CurrencyDollars)
+		collector.ObserveCache("WhatsOnChain", bsvrates.CurrencyDollars, bsvrates.CacheHit)
+		collector.ObserveCache("WhatsOnChain", bsvrates.CurrencyDollars, bsvrates.CacheHit)
+
+		buf := new(bytes.Buffer)
+		_, err := collector.WriteTo(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `# HELP bsvrates_provider_requests_total Requests to the rate providers.
+# TYPE bsvrates_provider_requests_total counter
+bsvrates_provider_requests_total{provider="CoinPaprika",currency="usd",result="error",status_code="429"} 1
+bsvrates_provider_requests_total{provider="WhatsOnChain",currency="usd",result="success",status_code="200"} 2
+# HELP bsvrates_provider_request_duration_seconds Latency of the requests to the rate providers.
+# TYPE bsvrates_provider_request_duration_seconds histogram
+bsvrates_provider_request_duration_seconds_bucket{provider="CoinPaprika",le="0.01"} 1
+bsvrates_provider_request_duration_seconds_bucket{provider="CoinPaprika",le="0.1"} 1
+bsvrates_provider_request_duration_seconds_bucket{provider="CoinPaprika",le="+Inf"} 1
+bsvrates_provider_request_duration_seconds_sum{provider="CoinPaprika"} 0.005
+bsvrates_provider_request_duration_seconds_count{provider="CoinPaprika"} 1
+bsvrates_provider_request_duration_seconds_bucket{provider="WhatsOnChain",le="0.01"} 0
+bsvrates_provider_request_duration_seconds_bucket{provider="WhatsOnChain",le="0.1"} 1
+bsvrates_provider_request_duration_seconds_bucket{provider="WhatsOnChain",le="+Inf"} 2
+bsvrates_provider_request_duration_seconds_sum{provider="WhatsOnChain"} 1.05
+bsvrates_provider_request_duration_seconds_count{provider="WhatsOnChain"} 2
+# HELP bsvrates_provider_fallbacks_total Fallbacks from a failed provider to the next provider.
+# TYPE bsvrates_provider_fallbacks_total counter
+bsvrates_provider_fallbacks_total{provider="CoinPaprika",currency="usd"} 1
+# HELP bsvrates_cache_lookups_total Rate cache lookups by status.
+# TYPE bsvrates_cache_lookups_total counter
+bsvrates_cache_lookups_total{provider="WhatsOnChain",currency="usd",status="hit"} 2
+`, buf.String())
+	})
+}
+
+// TestCollector_ServeHTTP will test the method ServeHTTP()
+func TestCollector_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	collector := NewCollector()
+	collector.ObserveFallback("CoinPaprika", bsvrates.CurrencyDollars)
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, textFormatContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `bsvrates_provider_fallbacks_total{provider="CoinPaprika",currency="usd"} 1`)
+}
+
+// TestLabels will test the method labels()
+func TestLabels(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		pairs    []string
+		expected string
+	}{
+		{[]string{"provider", "CoinPaprika"}, `provider="CoinPaprika"`},
+		{[]string{"provider", "a", "currency", "usd"}, `provider="a",currency="usd"`},
+		{[]string{"provider", `in "house"` + "\n" + `\`}, `provider="in \"house\"\n\\"`},
+		{[]string{}, ``},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, labels(test.pairs...))
+	}
+}
+
+// TestCollector_Client will test the collector with a client
+func TestCollector_Client(t *testing.T) {
+	t.Parallel()
+
+	collector := NewCollector()
+	options := bsvrates.DefaultClientOptions()
+	options.CustomProviders = []bsvrates.RateProvider{
+		&mockProvider{name: "down"},
+		&mockProvider{name: "in-house", rate: 150},
+	}
+	options.Metrics = collector
+	client := bsvrates.NewClient(options, nil)
+
+	_, _, err := client.GetRate(context.Background(), bsvrates.CurrencyDollars)
+	assert.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	_, err = collector.WriteTo(buf)
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.True(t, strings.Contains(output,
+		`bsvrates_provider_requests_total{provider="down",currency="usd",result="error",status_code="none"} 1`))
+	assert.True(t, strings.Contains(output,
+		`bsvrates_provider_requests_total{provider="in-house",currency="usd",result="success",status_code="none"} 1`))
+	assert.True(t, strings.Contains(output,
+		`bsvrates_provider_fallbacks_total{provider="down",currency="usd"} 1`))
+}
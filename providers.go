@@ -0,0 +1,369 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mrz1836/go-whatsonchain"
+	"github.com/shopspring/decimal"
+)
+
+// coinPaprikaProvider adapts the Coin Paprika client to the RateProvider interface
+type coinPaprikaProvider struct {
+	client *Client // Parent client (uses the current Coin Paprika client)
+}
+
+// Name will return the display name of the provider
+func (p *coinPaprikaProvider) Name() string {
+	return ProviderCoinPaprika.Name()
+}
+
+// SupportsCurrency will return true if Coin Paprika can quote the currency
+func (p *coinPaprikaProvider) SupportsCurrency(currency Currency) bool {
+	return currency.IsFiat() && p.client.CoinPaprika().IsAcceptedCurrency(currency.Name())
+}
+
+// GetRate will get the BSV->Currency rate from Coin Paprika
+func (p *coinPaprikaProvider) GetRate(ctx context.Context, currency Currency) (rate float64, err error) {
+	var quote *Quote
+	if quote, err = p.GetQuote(ctx, currency); err == nil {
+		rate = quote.Rate
+	}
+	return
+}
+
+// GetQuote will get the BSV->Currency quote from Coin Paprika
+// (USD uses the market price, all other currencies use the price converter)
+func (p *coinPaprikaProvider) GetQuote(ctx context.Context, currency Currency) (quote *Quote, err error) {
+
+	// Use the market price (USD)
+	quote = new(Quote)
+	if currency == CurrencyDollars {
+		var response *TickerResponse
+		if response, err = p.client.CoinPaprika().GetMarketPrice(
+			ctx, CoinPaprikaQuoteID,
+		); err != nil && response != nil {
+			err = withLastRequest(err, response.LastRequest)
+		} else if err == nil && response != nil && response.Quotes != nil && response.Quotes.USD != nil {
+			quote.Rate = response.Quotes.USD.Price
+			quote.QuotedAt = parseQuoteTime(response.LastUpdated)
+			quote.Volume24h = response.Quotes.USD.Volume24h
+		}
+		return
+	}
+
+	// Convert 1 BSV into the currency
+	currencyID, _ := p.client.CoinPaprika().GetBaseAmountAndCurrencyID(currency.Name(), 1)
+	var response *PriceConversionResponse
+	if response, err = p.client.CoinPaprika().GetPriceConversion(
+		ctx, CoinPaprikaQuoteID, currencyID, 1,
+	); err != nil && response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	} else if err == nil && response != nil {
+		quote.Rate = response.Price
+		quote.QuotedAt = parseQuoteTime(response.BasePriceLastUpdated)
+	}
+	return
+}
+
+// GetConversion will get the satoshi amount for the given currency + amount from Coin Paprika
+func (p *coinPaprikaProvider) GetConversion(ctx context.Context, currency Currency,
+	amount float64) (satoshis int64, err error) {
+
+	// Get the currency ID
+	currencyID, _ := p.client.CoinPaprika().GetBaseAmountAndCurrencyID(currency.Name(), amount)
+	if len(currencyID) == 0 {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not accepted by %s", currency.Name(), p.Name())
+		return
+	}
+
+	// Convert the amount into BSV
+	var response *PriceConversionResponse
+	if response, err = p.client.CoinPaprika().GetPriceConversion(
+		ctx, currencyID, CoinPaprikaQuoteID, amount,
+	); err != nil && response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	} else if err == nil && response != nil {
+		satoshis, err = response.GetSatoshi()
+	}
+	return
+}
+
+// HealthCheck will check Coin Paprika (using the global market overview)
+func (p *coinPaprikaProvider) HealthCheck(ctx context.Context) error {
+	response, err := p.client.CoinPaprika().GetGlobal(ctx)
+	if response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	}
+	return err
+}
+
+// whatsOnChainProvider adapts the WhatsOnChain client to the RateProvider interface
+type whatsOnChainProvider struct {
+	client *Client // Parent client (uses the current WhatsOnChain client)
+}
+
+// Name will return the display name of the provider
+func (p *whatsOnChainProvider) Name() string {
+	return ProviderWhatsOnChain.Name()
+}
+
+// SupportsCurrency will return true if WhatsOnChain can quote the currency (USD only)
+func (p *whatsOnChainProvider) SupportsCurrency(currency Currency) bool {
+	return currency == CurrencyDollars
+}
+
+// GetRate will get the BSV->Currency rate from WhatsOnChain
+func (p *whatsOnChainProvider) GetRate(ctx context.Context, currency Currency) (rate float64, err error) {
+	var quote *Quote
+	if quote, err = p.GetQuote(ctx, currency); err == nil {
+		rate = quote.Rate
+	}
+	return
+}
+
+// GetQuote will get the BSV->Currency quote from WhatsOnChain
+func (p *whatsOnChainProvider) GetQuote(ctx context.Context, _ Currency) (quote *Quote, err error) {
+	var response *whatsonchain.ExchangeRate
+	quote = new(Quote)
+	if response, err = p.client.WhatsOnChain().GetExchangeRate(ctx); err == nil && response != nil {
+		quote.Rate = response.Rate
+		if response.Time > 0 {
+			quote.QuotedAt = time.Unix(response.Time, 0).UTC()
+		}
+	}
+	return
+}
+
+// HealthCheck will check WhatsOnChain (using the chain info)
+func (p *whatsOnChainProvider) HealthCheck(ctx context.Context) error {
+	_, err := p.client.WhatsOnChain().GetChainInfo(ctx)
+	return err
+}
+
+// GetConversion will get the satoshi amount for the given currency + amount from WhatsOnChain
+func (p *whatsOnChainProvider) GetConversion(ctx context.Context, currency Currency,
+	amount float64) (satoshis int64, err error) {
+	var rate float64
+	if rate, err = p.GetRate(ctx, currency); err == nil && rate > 0 {
+		satoshis, err = ConvertPriceToSatoshis(rate, amount)
+	}
+	return
+}
+
+// coinGeckoProvider adapts the CoinGecko client to the RateProvider interface
+type coinGeckoProvider struct {
+	client *Client // Parent client (uses the current CoinGecko client)
+}
+
+// Name will return the display name of the provider
+func (p *coinGeckoProvider) Name() string {
+	return ProviderCoinGecko.Name()
+}
+
+// SupportsCurrency will return true if CoinGecko can quote the currency
+func (p *coinGeckoProvider) SupportsCurrency(currency Currency) bool {
+	return p.client.CoinGecko().IsAcceptedCurrency(currency.Name())
+}
+
+// GetRate will get the BSV->Currency rate from CoinGecko
+func (p *coinGeckoProvider) GetRate(ctx context.Context, currency Currency) (rate float64, err error) {
+	var quote *Quote
+	if quote, err = p.GetQuote(ctx, currency); err == nil {
+		rate = quote.Rate
+	}
+	return
+}
+
+// GetQuote will get the BSV->Currency quote from CoinGecko (using the simple price)
+func (p *coinGeckoProvider) GetQuote(ctx context.Context, currency Currency) (quote *Quote, err error) {
+	var response *SimplePriceResponse
+	quote = new(Quote)
+	if response, err = p.client.CoinGecko().GetSimplePrice(
+		ctx, CoinGeckoCoinID, currency.Name(),
+	); err != nil && response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	} else if err == nil && response != nil {
+		quote.Rate = response.Prices[currency.Name()]
+		quote.Volume24h = response.Volumes24h[currency.Name()]
+		if response.LastUpdatedAt > 0 {
+			quote.QuotedAt = time.Unix(response.LastUpdatedAt, 0).UTC()
+		}
+	}
+	return
+}
+
+// GetConversion will get the satoshi amount for the given currency + amount from CoinGecko
+func (p *coinGeckoProvider) GetConversion(ctx context.Context, currency Currency,
+	amount float64) (satoshis int64, err error) {
+	var rate float64
+	if rate, err = p.GetRate(ctx, currency); err == nil && rate > 0 {
+		satoshis, err = ConvertPriceToSatoshis(rate, amount)
+	}
+	return
+}
+
+// HealthCheck will check CoinGecko (using the ping)
+func (p *coinGeckoProvider) HealthCheck(ctx context.Context) error {
+	response, err := p.client.CoinGecko().Ping(ctx)
+	if response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	}
+	return err
+}
+
+// bitfinexProvider adapts the Bitfinex client to the RateProvider interface
+// (the rate is the last trade price, with the bid, ask and volume)
+type bitfinexProvider struct {
+	client *Client // Parent client (uses the current Bitfinex client)
+}
+
+// Name will return the display name of the provider
+func (p *bitfinexProvider) Name() string {
+	return ProviderBitfinex.Name()
+}
+
+// SupportsCurrency will return true if Bitfinex has a BSV trading pair for the currency
+func (p *bitfinexProvider) SupportsCurrency(currency Currency) bool {
+	return p.client.Bitfinex().IsAcceptedCurrency(currency.Name())
+}
+
+// GetRate will get the BSV->Currency rate (last trade price) from Bitfinex
+func (p *bitfinexProvider) GetRate(ctx context.Context, currency Currency) (rate float64, err error) {
+	var quote *Quote
+	if quote, err = p.GetQuote(ctx, currency); err == nil {
+		rate = quote.Rate
+	}
+	return
+}
+
+// GetQuote will get the BSV->Currency quote from the Bitfinex ticker (last, bid, ask and volume)
+func (p *bitfinexProvider) GetQuote(ctx context.Context, currency Currency) (quote *Quote, err error) {
+	var response *TickerBitfinex
+	quote = new(Quote)
+	if response, err = p.client.Bitfinex().GetTicker(
+		ctx, BitfinexSymbol(currency.Name()),
+	); err != nil && response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	} else if err == nil && response != nil {
+		quote.Ask = response.Ask
+		quote.Bid = response.Bid
+		quote.Rate = response.LastPrice
+		quote.Volume24h, _ = decimal.NewFromFloat(response.Volume).Mul(
+			decimal.NewFromFloat(response.LastPrice),
+		).Round(2).Float64()
+	}
+	return
+}
+
+// HealthCheck will check Bitfinex with a USD ticker request
+func (p *bitfinexProvider) HealthCheck(ctx context.Context) error {
+	response, err := p.client.Bitfinex().GetTicker(ctx, BitfinexSymbol(usd))
+	if response != nil {
+		err = withLastRequest(err, response.LastRequest)
+	}
+	return err
+}
+
+// GetConversion will get the satoshi amount for the given currency + amount from Bitfinex
+func (p *bitfinexProvider) GetConversion(ctx context.Context, currency Currency,
+	amount float64) (satoshis int64, err error) {
+	var rate float64
+	if rate, err = p.GetRate(ctx, currency); err == nil && rate > 0 {
+		satoshis, err = ConvertPriceToSatoshis(rate, amount)
+	}
+	return
+}
+
+// requestQuote will request the quote from the provider
+// (limited by the rate limiter, skipped without using the limit if the circuit is open,
+// and recorded with recordRequest)
+func (c *Client) requestQuote(ctx context.Context, provider RateProvider, currency Currency) (quote *Quote, err error) {
+	if err = c.limiters.wait(ctx, provider.Name()); err != nil {
+		return
+	} else if err = c.breakers.allow(provider.Name()); err != nil {
+		c.limiters.refund(provider.Name())
+		return
+	}
+	start := time.Now()
+	quote, err = getQuote(ctx, provider, currency)
+	c.recordRequest(provider, currency, err, time.Since(start))
+	return
+}
+
+// requestConversion will request the conversion from the provider
+// (limited by the rate limiter, skipped without using the limit if the circuit is open,
+// and recorded with recordRequest)
+func (c *Client) requestConversion(ctx context.Context, provider RateProvider, currency Currency,
+	amount float64) (satoshis int64, err error) {
+	if err = c.limiters.wait(ctx, provider.Name()); err != nil {
+		return
+	} else if err = c.breakers.allow(provider.Name()); err != nil {
+		c.limiters.refund(provider.Name())
+		return
+	}
+	start := time.Now()
+	satoshis, err = provider.GetConversion(ctx, currency, amount)
+	c.recordRequest(provider, currency, err, time.Since(start))
+	return
+}
+
+// recordRequest will record the result of the request for the breaker, health, rate limiter and metrics
+// (requests cancelled by the caller, such as the losers of a hedged request, are not counted as failures)
+func (c *Client) recordRequest(provider RateProvider, currency Currency, err error, latency time.Duration) {
+	c.breakers.record(provider.Name(), err)
+	c.health.record(provider.Name(), err, latency)
+	c.limiters.record(provider.Name(), err)
+	if !errors.Is(err, context.Canceled) {
+		c.metrics.ObserveProviderRequest(provider.Name(), currency, requestStatusCode(provider, err), err, latency)
+	}
+}
+
+// requestStatusCode will return the HTTP status code of the request to the provider for the metrics
+// (the status of a failed request, 200 for a built-in provider that succeeded, 0 if unknown)
+func requestStatusCode(provider RateProvider, err error) int {
+	if err != nil {
+		return errorStatusCode(err)
+	} else if providerType(provider) != ProviderCustom {
+		return http.StatusOK
+	}
+	return 0
+}
+
+// newBuiltInProvider will return the RateProvider for the given Provider constant
+func newBuiltInProvider(c *Client, provider Provider) (RateProvider, error) {
+	switch provider {
+	case ProviderCoinPaprika:
+		return &coinPaprikaProvider{client: c}, nil
+	case ProviderWhatsOnChain:
+		return &whatsOnChainProvider{client: c}, nil
+	case ProviderCoinGecko:
+		return &coinGeckoProvider{client: c}, nil
+	case ProviderBitfinex:
+		return &bitfinexProvider{client: c}, nil
+	case providerLast, ProviderCustom:
+		return nil, fmt.Errorf("provider unknown")
+	default:
+		return nil, fmt.Errorf("provider unknown")
+	}
+}
+
+// providerType will return the Provider constant for the given RateProvider
+// (any provider that is not built-in is reported as ProviderCustom)
+func providerType(provider RateProvider) Provider {
+	switch provider.(type) {
+	case *coinPaprikaProvider:
+		return ProviderCoinPaprika
+	case *whatsOnChainProvider:
+		return ProviderWhatsOnChain
+	case *coinGeckoProvider:
+		return ProviderCoinGecko
+	case *bitfinexProvider:
+		return ProviderBitfinex
+	default:
+		return ProviderCustom
+	}
+}
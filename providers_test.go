@@ -0,0 +1,148 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewClient_CustomProviders will test registering custom providers
+func TestNewClient_CustomProviders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only custom providers", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house", rate: 160.5}}
+		client := NewClient(options, nil)
+		assert.NotNil(t, client)
+
+		assert.Equal(t, []Provider{ProviderCustom}, client.Providers())
+		assert.Equal(t, 1, len(client.RateProviders()))
+		assert.Equal(t, "in-house", client.RateProviders()[0].Name())
+	})
+
+	t.Run("built-in then custom providers", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house", rate: 160.5}}
+		client := NewClient(options, nil, ProviderWhatsOnChain)
+		assert.NotNil(t, client)
+
+		assert.Equal(t, []Provider{ProviderWhatsOnChain, ProviderCustom}, client.Providers())
+		assert.Equal(t, "WhatsOnChain", client.RateProviders()[0].Name())
+		assert.Equal(t, "in-house", client.RateProviders()[1].Name())
+	})
+
+	t.Run("unknown providers are skipped", func(t *testing.T) {
+		client := NewClient(nil, nil, providerLast, ProviderCoinPaprika, ProviderCustom)
+		assert.NotNil(t, client)
+
+		assert.Equal(t, []Provider{ProviderCoinPaprika}, client.Providers())
+	})
+
+	t.Run("add provider", func(t *testing.T) {
+		client := NewClient(nil, nil, ProviderCoinPaprika)
+		assert.NotNil(t, client)
+
+		client.AddProvider(nil)
+		client.AddProvider(&mockRateProvider{name: "in-house"})
+		assert.Equal(t, []Provider{ProviderCoinPaprika, ProviderCustom}, client.Providers())
+	})
+
+	t.Run("rate providers is a copy", func(t *testing.T) {
+		client := NewClient(nil, nil, ProviderCoinPaprika)
+		client.RateProviders()[0] = &mockRateProvider{name: "in-house"}
+		assert.Equal(t, []Provider{ProviderCoinPaprika}, client.Providers())
+	})
+
+	t.Run("add provider while requests are running", func(t *testing.T) {
+		client := NewClient(newMockOptions(&mockRateProvider{name: "first", rate: 150}), nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _, _ = client.GetRate(context.Background(), CurrencyDollars)
+			}()
+			go func() {
+				defer wg.Done()
+				client.AddProvider(&mockRateProvider{name: "in-house", rate: 160})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 11, len(client.RateProviders()))
+	})
+}
+
+// TestClient_CustomProviders will test rates and conversions using custom providers
+func TestClient_CustomProviders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fail-over to a custom provider", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
+		client.AddProvider(&mockRateProvider{name: "in-house", rate: 160.5, satoshis: 623053})
+
+		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, 160.5, rate)
+		assert.Equal(t, ProviderCustom, provider)
+		assert.Equal(t, true, provider.IsValid())
+
+		var satoshis int64
+		satoshis, provider, err = client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(623053), satoshis)
+		assert.Equal(t, ProviderCustom, provider)
+	})
+
+	t.Run("custom provider fails", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house"}}
+		client := NewClient(options, nil)
+
+		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.Equal(t, float64(0), rate)
+		assert.Equal(t, ProviderCustom, provider)
+	})
+}
+
+// TestProviderType will test the method providerType()
+func TestProviderType(t *testing.T) {
+	t.Parallel()
+
+	client := new(Client)
+	assert.Equal(t, ProviderCoinPaprika, providerType(&coinPaprikaProvider{client: client}))
+	assert.Equal(t, ProviderWhatsOnChain, providerType(&whatsOnChainProvider{client: client}))
+	assert.Equal(t, ProviderCustom, providerType(&mockRateProvider{name: "in-house"}))
+}
+
+// TestProvider_SupportsCurrency will test the per-provider currency capabilities
+func TestProvider_SupportsCurrency(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(nil, &mockWOCValid{}, createPaprikaClient(nil, &mockHTTPPaprika{})).(*Client)
+	paprika := &coinPaprikaProvider{client: client}
+	woc := &whatsOnChainProvider{client: client}
+
+	var tests = []struct {
+		testCase        string
+		currency        Currency
+		expectedPaprika bool
+		expectedWOC     bool
+	}{
+		{"CurrencyDollars", CurrencyDollars, true, true},
+		{"CurrencyEuro", CurrencyEuro, true, false},
+		{"CurrencyNewZealandDollar", CurrencyNewZealandDollar, true, false},
+		{"CurrencyBitcoin", CurrencyBitcoin, false, false},
+		{"currency 0", 0, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.testCase, func(t *testing.T) {
+			assert.Equal(t, test.expectedPaprika, paprika.SupportsCurrency(test.currency))
+			assert.Equal(t, test.expectedWOC, woc.SupportsCurrency(test.currency))
+		})
+	}
+}
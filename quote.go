@@ -0,0 +1,68 @@
+package bsvrates
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Quote is a BSV->Currency rate quoted by a provider
+type Quote struct {
+	Ask       float64   `json:"ask"`        // Lowest ask price (exchanges only, zero if unknown)
+	Bid       float64   `json:"bid"`        // Highest bid price (exchanges only, zero if unknown)
+	FetchedAt time.Time `json:"fetched_at"` // When the quote was fetched from the provider (set by the client)
+	QuotedAt  time.Time `json:"quoted_at"`  // When the provider last updated the rate (zero if unknown)
+	Rate      float64   `json:"rate"`       // BSV->Currency rate (the last trade price for exchanges)
+	Volume24h float64   `json:"volume_24h"` // 24 hour volume in the currency (zero if unknown)
+}
+
+// Age will return how old the quote is
+// (since the quote time, or the fetch time if the provider does not report one)
+func (q *Quote) Age() time.Duration {
+	if q.QuotedAt.IsZero() {
+		return time.Since(q.FetchedAt)
+	}
+	return time.Since(q.QuotedAt)
+}
+
+// checkAge will return ErrStaleQuote if the quote is older than the maximum age (0 disables)
+func (q *Quote) checkAge(maxAge time.Duration) error {
+	if age := q.Age(); maxAge > 0 && age > maxAge {
+		return fmt.Errorf("%w: quoted %s ago (maximum %s)", ErrStaleQuote, age.Round(time.Second), maxAge)
+	}
+	return nil
+}
+
+// getQuote will get the quote from the provider
+// (providers that do not implement QuoteProvider only return the rate)
+func getQuote(ctx context.Context, provider RateProvider, currency Currency) (quote *Quote, err error) {
+
+	// Get the quote (or rate)
+	if quoteProvider, ok := provider.(QuoteProvider); ok {
+		quote, err = quoteProvider.GetQuote(ctx, currency)
+	} else {
+		var rate float64
+		if rate, err = provider.GetRate(ctx, currency); err == nil {
+			quote = &Quote{Rate: rate}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the rate
+	if quote == nil || quote.Rate <= 0 {
+		return nil, fmt.Errorf("no rate returned from %s", provider.Name())
+	}
+	quote.FetchedAt = time.Now().UTC()
+	return
+}
+
+// parseQuoteTime will parse the RFC3339 time from a provider (zero if invalid)
+func parseQuoteTime(value string) time.Time {
+	quotedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return quotedAt.UTC()
+}
@@ -0,0 +1,91 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetQuote will test the method getQuote()
+func TestGetQuote(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate provider - no quote time", func(t *testing.T) {
+		quote, err := getQuote(context.Background(), &mockRateProvider{name: "in-house", rate: 150}, CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, quote)
+		assert.Equal(t, float64(150), quote.Rate)
+		assert.True(t, quote.QuotedAt.IsZero())
+		assert.False(t, quote.FetchedAt.IsZero())
+	})
+
+	t.Run("rate provider - error", func(t *testing.T) {
+		quote, err := getQuote(context.Background(), &mockRateProvider{name: "in-house"}, CurrencyDollars)
+		assert.Error(t, err)
+		assert.Nil(t, quote)
+	})
+
+	t.Run("quote provider - zero rate", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaBase{})
+		quote, err := getQuote(context.Background(), &coinPaprikaProvider{client: client.(*Client)}, CurrencyDollars)
+		assert.Error(t, err)
+		assert.Nil(t, quote)
+	})
+}
+
+// TestParseQuoteTime will test the method parseQuoteTime()
+func TestParseQuoteTime(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2020-07-01T18:36:56Z", time.Date(2020, 7, 1, 18, 36, 56, 0, time.UTC)},
+		{"2020-07-01T20:36:56+02:00", time.Date(2020, 7, 1, 18, 36, 56, 0, time.UTC)},
+		{"", time.Time{}},
+		{"not-a-time", time.Time{}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseQuoteTime(test.input))
+	}
+}
+
+// TestQuote_Age will test the method Age()
+func TestQuote_Age(t *testing.T) {
+	t.Parallel()
+
+	t.Run("quote time", func(t *testing.T) {
+		quote := &Quote{FetchedAt: time.Now(), QuotedAt: time.Now().Add(-time.Hour), Rate: 150}
+		assert.True(t, quote.Age() >= time.Hour)
+	})
+
+	t.Run("no quote time - uses fetch time", func(t *testing.T) {
+		quote := &Quote{FetchedAt: time.Now().Add(-time.Minute), Rate: 150}
+		assert.True(t, quote.Age() >= time.Minute)
+		assert.True(t, quote.Age() < time.Hour)
+	})
+}
+
+// TestQuote_CheckAge will test the method checkAge()
+func TestQuote_CheckAge(t *testing.T) {
+	t.Parallel()
+
+	quote := &Quote{FetchedAt: time.Now(), QuotedAt: time.Now().Add(-time.Hour), Rate: 150}
+
+	var tests = []struct {
+		maxAge        time.Duration
+		expectedStale bool
+	}{
+		{0, false},
+		{2 * time.Hour, false},
+		{time.Minute, true},
+	}
+	for _, test := range tests {
+		err := quote.checkAge(test.maxAge)
+		assert.Equal(t, test.expectedStale, errors.Is(err, ErrStaleQuote))
+	}
+}
@@ -0,0 +1,167 @@
+package bsvrates
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit is the client-side request limit for a provider (see ClientOptions.RateLimits)
+type RateLimit struct {
+	Burst             int     `json:"burst"`               // Requests allowed at once (defaults to 1)
+	MonthlyBudget     int     `json:"monthly_budget"`      // Requests allowed per calendar month (UTC) (0 is unlimited)
+	RequestsPerSecond float64 `json:"requests_per_second"` // Sustained requests per second (0 is unlimited)
+	Wait              bool    `json:"wait"`                // Queue the request until it is allowed (instead of failing fast)
+}
+
+// burst will return the requests allowed at once (at least one)
+func (l RateLimit) burst() float64 {
+	if l.Burst > 1 {
+		return float64(l.Burst)
+	}
+	return 1
+}
+
+// rateLimiter is a token bucket (and monthly budget) for a single provider
+type rateLimiter struct {
+	last    time.Time  // When the tokens were last refilled
+	limit   RateLimit  // Configured limit
+	month   time.Time  // Start of the current budget month
+	mu      sync.Mutex // Guards the limiter
+	retryAt time.Time  // The provider asked us not to retry before this (Retry-After)
+	tokens  float64    // Available tokens
+	used    int        // Requests used in the current budget month
+}
+
+// reserve will take a token if the request is allowed now, otherwise it returns how long
+// to wait before trying again (an error is returned if waiting will not help)
+func (l *rateLimiter) reserve(now time.Time) (wait time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The provider asked us to back off
+	if now.Before(l.retryAt) {
+		wait = l.retryAt.Sub(now)
+		return
+	}
+
+	// The monthly budget is used (resets at the start of the next month)
+	if l.limit.MonthlyBudget > 0 {
+		if month := startOfMonth(now); !month.Equal(l.month) {
+			l.month, l.used = month, 0
+		}
+		if l.used >= l.limit.MonthlyBudget {
+			err = newKindError(ErrRateLimited, "monthly budget of %d requests is used", l.limit.MonthlyBudget)
+			return
+		}
+	}
+
+	// Refill the token bucket
+	if l.limit.RequestsPerSecond > 0 {
+		if l.last.IsZero() {
+			l.tokens = l.limit.burst()
+		} else if l.tokens += now.Sub(l.last).Seconds() * l.limit.RequestsPerSecond; l.tokens > l.limit.burst() {
+			l.tokens = l.limit.burst()
+		}
+		l.last = now
+		if l.tokens < 1 {
+			wait = time.Duration((1 - l.tokens) / l.limit.RequestsPerSecond * float64(time.Second))
+			return
+		}
+		l.tokens--
+	}
+	l.used++
+	return
+}
+
+// refund will return the token and budget taken by reserve (the request was not sent)
+func (l *rateLimiter) refund() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit.RequestsPerSecond > 0 {
+		if l.tokens++; l.tokens > l.limit.burst() {
+			l.tokens = l.limit.burst()
+		}
+	}
+	if l.used > 0 {
+		l.used--
+	}
+}
+
+// backoff will block requests until the delay passes (IE: Retry-After on a 429)
+func (l *rateLimiter) backoff(now time.Time, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if retryAt := now.Add(delay); retryAt.After(l.retryAt) {
+		l.retryAt = retryAt
+	}
+}
+
+// startOfMonth will return the start of the calendar month (UTC)
+func startOfMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// rateLimiters is the rate limiter for each provider (keyed by provider name)
+type rateLimiters struct {
+	limiters map[string]*rateLimiter // Limiters by provider name
+	limits   map[string]RateLimit    // Configured limits by provider name
+	mu       sync.Mutex              // Guards the limiters map
+}
+
+// newRateLimiters will return the rate limiters for the configured limits
+// (providers without a limit still honor Retry-After)
+func newRateLimiters(limits map[string]RateLimit) *rateLimiters {
+	return &rateLimiters{
+		limiters: make(map[string]*rateLimiter),
+		limits:   limits,
+	}
+}
+
+// get will return the limiter for the provider (created if not found)
+func (r *rateLimiters) get(name string) *rateLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limiter, ok := r.limiters[name]
+	if !ok {
+		limiter = &rateLimiter{limit: r.limits[name]}
+		r.limiters[name] = limiter
+	}
+	return limiter
+}
+
+// wait will wait until a request to the provider is allowed. If the limit is not
+// set to Wait (or the monthly budget is used), an ErrRateLimited error is returned instead
+func (r *rateLimiters) wait(ctx context.Context, name string) error {
+	limiter := r.get(name)
+	for {
+		delay, err := limiter.reserve(time.Now())
+		if err != nil || delay <= 0 {
+			return err
+		} else if !limiter.limit.Wait {
+			return newKindError(ErrRateLimited, "rate limited for another %s", delay.Round(time.Millisecond))
+		}
+
+		// Wait for the next token (or the context to end)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// refund will return the reservation taken by wait (IE: the circuit breaker skipped the request)
+func (r *rateLimiters) refund(name string) {
+	r.get(name).refund()
+}
+
+// record will back off from the provider if it asked us to (Retry-After)
+func (r *rateLimiters) record(name string, err error) {
+	if delay := errorRetryAfter(err); delay > 0 {
+		r.get(name).backoff(time.Now(), delay)
+	}
+}
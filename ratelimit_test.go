@@ -0,0 +1,258 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimit_Burst will test the method burst()
+func TestRateLimit_Burst(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		burst         int
+		expectedBurst float64
+	}{
+		{0, 1},
+		{-1, 1},
+		{1, 1},
+		{5, 5},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedBurst, RateLimit{Burst: test.burst}.burst())
+	}
+}
+
+// TestRateLimiter_Reserve will test the method reserve()
+func TestRateLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 6, 28, 19, 0, 0, 0, time.UTC)
+
+	t.Run("no limit", func(t *testing.T) {
+		limiter := &rateLimiter{}
+		for i := 0; i < 10; i++ {
+			wait, err := limiter.reserve(now)
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+	})
+
+	t.Run("token bucket", func(t *testing.T) {
+		limiter := &rateLimiter{limit: RateLimit{Burst: 2, RequestsPerSecond: 2}}
+
+		// Burst is allowed
+		for i := 0; i < 2; i++ {
+			wait, err := limiter.reserve(now)
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+
+		// Then wait for the next token
+		wait, err := limiter.reserve(now)
+		assert.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, wait)
+
+		wait, err = limiter.reserve(now.Add(250 * time.Millisecond))
+		assert.NoError(t, err)
+		assert.Equal(t, 250*time.Millisecond, wait)
+
+		wait, err = limiter.reserve(now.Add(500 * time.Millisecond))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+
+		// Refill is capped at the burst
+		for i := 0; i < 2; i++ {
+			wait, err = limiter.reserve(now.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+		wait, err = limiter.reserve(now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, wait)
+	})
+
+	t.Run("monthly budget", func(t *testing.T) {
+		limiter := &rateLimiter{limit: RateLimit{MonthlyBudget: 2}}
+		for i := 0; i < 2; i++ {
+			_, err := limiter.reserve(now)
+			assert.NoError(t, err)
+		}
+
+		_, err := limiter.reserve(now)
+		assert.EqualError(t, err, "monthly budget of 2 requests is used")
+		assert.True(t, errors.Is(err, ErrRateLimited))
+
+		// Resets next month
+		_, err = limiter.reserve(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+	})
+
+	t.Run("waiting for a token does not use the budget", func(t *testing.T) {
+		limiter := &rateLimiter{limit: RateLimit{MonthlyBudget: 2, RequestsPerSecond: 1}}
+		_, err := limiter.reserve(now)
+		assert.NoError(t, err)
+
+		wait, err := limiter.reserve(now)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, wait)
+
+		_, err = limiter.reserve(now.Add(time.Second))
+		assert.NoError(t, err)
+
+		_, err = limiter.reserve(now.Add(time.Minute))
+		assert.True(t, errors.Is(err, ErrRateLimited))
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		limiter := &rateLimiter{}
+		limiter.backoff(now, time.Minute)
+
+		// A shorter delay does not shorten the back off
+		limiter.backoff(now, time.Second)
+
+		wait, err := limiter.reserve(now.Add(10 * time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, 50*time.Second, wait)
+
+		wait, err = limiter.reserve(now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	})
+}
+
+// TestRateLimiters_Wait will test the method wait()
+func TestRateLimiters_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fail fast", func(t *testing.T) {
+		limiters := newRateLimiters(map[string]RateLimit{"test": {RequestsPerSecond: 0.01}})
+		assert.NoError(t, limiters.wait(context.Background(), "test"))
+
+		err := limiters.wait(context.Background(), "test")
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrRateLimited))
+
+		// Other providers are not affected
+		assert.NoError(t, limiters.wait(context.Background(), "other"))
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		limiters := newRateLimiters(map[string]RateLimit{"test": {RequestsPerSecond: 20, Wait: true}})
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, limiters.wait(context.Background(), "test"))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("queue - context cancelled", func(t *testing.T) {
+		limiters := newRateLimiters(map[string]RateLimit{"test": {RequestsPerSecond: 0.01, Wait: true}})
+		assert.NoError(t, limiters.wait(context.Background(), "test"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.True(t, errors.Is(limiters.wait(ctx, "test"), context.DeadlineExceeded))
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		limiters := newRateLimiters(nil)
+		limiters.record("test", errors.New("failed"))
+		assert.NoError(t, limiters.wait(context.Background(), "test"))
+
+		limiters.record("test", &ProviderHTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute})
+		assert.True(t, errors.Is(limiters.wait(context.Background(), "test"), ErrRateLimited))
+	})
+}
+
+// TestClient_RateLimits will test the client-side rate limits
+func TestClient_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate limited provider falls over", func(t *testing.T) {
+		limited := &mockRateProvider{name: "limited", rate: 100}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{limited, &mockRateProvider{name: "in-house", rate: 150}}
+		options.RateLimits = map[string]RateLimit{"limited": {MonthlyBudget: 1}}
+		client := NewClient(options, nil)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "limited", result.ProviderName)
+
+		result, err = client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "in-house", result.ProviderName)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.True(t, errors.Is(result.Errors[0], ErrRateLimited))
+		}
+		assert.Equal(t, int64(1), limited.rateCalls())
+	})
+
+	t.Run("retry after is honored", func(t *testing.T) {
+		limited := &mockRateProvider{name: "limited", err: &ProviderHTTPError{
+			RetryAfter: time.Minute,
+			StatusCode: http.StatusTooManyRequests,
+		}}
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{limited}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 3; i++ {
+			_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrRateLimited))
+		}
+		assert.Equal(t, int64(1), limited.rateCalls())
+	})
+
+	t.Run("open circuit does not use the budget", func(t *testing.T) {
+		failing := &mockRateProvider{name: "failing"}
+		options := DefaultClientOptions()
+		options.CircuitBreakerCooldown = time.Minute
+		options.CircuitBreakerThreshold = 1
+		options.CustomProviders = []RateProvider{failing}
+		options.RateLimits = map[string]RateLimit{"failing": {MonthlyBudget: 3}}
+		client := NewClient(options, nil)
+
+		for i := 0; i < 4; i++ {
+			_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+			assert.Error(t, err)
+			assert.False(t, errors.Is(err, ErrRateLimited))
+		}
+		assert.Equal(t, int64(1), failing.rateCalls())
+		assert.Equal(t, 1, client.(*Client).limiters.get("failing").used)
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		limiter := &rateLimiter{limit: RateLimit{Burst: 1, MonthlyBudget: 1, RequestsPerSecond: 0.01}}
+		now := time.Now()
+		_, err := limiter.reserve(now)
+		assert.NoError(t, err)
+		limiter.refund()
+
+		wait, err := limiter.reserve(now)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+		assert.Equal(t, 1, limiter.used)
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "limited", satoshis: 1000}}
+		options.RateLimits = map[string]RateLimit{"limited": {RequestsPerSecond: 0.01}}
+		client := NewClient(options, nil)
+
+		satoshis, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), satoshis)
+
+		_, _, err = client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.True(t, errors.Is(err, ErrRateLimited))
+	})
+}
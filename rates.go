@@ -5,49 +5,140 @@ package bsvrates
 
 import (
 	"context"
-	"fmt"
+	"time"
 
-	"github.com/mrz1836/go-whatsonchain"
+	"github.com/shopspring/decimal"
 )
 
+// RateResult is a BSV->Currency rate including where and when it was quoted
+type RateResult struct {
+	Ask          decimal.Decimal `json:"ask"`           // Lowest ask price (exchange providers only, zero if unknown)
+	Bid          decimal.Decimal `json:"bid"`           // Highest bid price (exchange providers only, zero if unknown)
+	Cache        CacheStatus     `json:"cache"`         // Cache status of the rate
+	Currency     Currency        `json:"currency"`      // Currency of the rate
+	Errors       ProviderErrors  `json:"errors"`        // Providers that failed before the rate was found
+	FetchedAt    time.Time       `json:"fetched_at"`    // When the rate was fetched from the provider
+	Provider     Provider        `json:"provider"`      // Provider used (ProviderCustom if not built-in)
+	ProviderName string          `json:"provider_name"` // Display name of the provider used
+	QuotedAt     time.Time       `json:"quoted_at"`     // When the provider last updated the rate (zero if unknown)
+	Rate         decimal.Decimal `json:"rate"`          // BSV->Currency rate
+	Volume24h    decimal.Decimal `json:"volume_24h"`    // 24 hour volume in the currency (zero if unknown)
+}
+
 // GetRate will get a BSV->Currency rate from the list of providers.
-// The first provider that succeeds is the rate that is returned
+// The first provider that succeeds is the rate that is returned.
+//
+// If ClientOptions.HedgeDelay is set, the next provider is also requested when a provider has
+// not answered within the delay (the first rate wins and the slower requests are cancelled).
+//
+// If the consensus rules are enabled (MinimumQuorum or MaxDeviationPercent), the median of
+// the agreeing providers is returned instead (see GetAggregatedRate)
 func (c *Client) GetRate(ctx context.Context, currency Currency) (rate float64, providerUsed Provider, err error) {
+	rate, providerUsed, _, err = c.GetCachedRate(ctx, currency)
+	return
+}
+
+// GetCachedRate is the same as GetRate but also returns the cache status of the rate
+// (CacheDisabled if ClientOptions.CacheTTL is not set)
+func (c *Client) GetCachedRate(ctx context.Context, currency Currency) (rate float64, providerUsed Provider,
+	status CacheStatus, err error) {
+	var result *RateResult
+	if result, providerUsed, status, err = c.getRate(ctx, currency); result != nil {
+		rate, _ = result.Rate.Float64()
+	}
+	return
+}
+
+// GetRateResult is the same as GetRate but returns the rate with its provenance: the provider,
+// the upstream quote time, the fetch time, the cache status and any providers that failed
+// before the rate was found
+func (c *Client) GetRateResult(ctx context.Context, currency Currency) (result *RateResult, err error) {
+	result, _, _, err = c.getRate(ctx, currency)
+	return
+}
+
+// getRate will get the rate from the first provider that succeeds
+// (the last provider attempted and its cache status are always returned)
+func (c *Client) getRate(ctx context.Context, currency Currency) (result *RateResult,
+	providerUsed Provider, status CacheStatus, err error) {
 
-	// Check if currency is accepted across all providers
+	// Trace the lookup
+	ctx, span := c.tracer.Start(ctx, spanRate, Attribute{Key: "currency", Value: currency.Name()})
+	defer func() {
+		endLookup(span, providerUsed, err)
+	}()
+
+	// Check if currency is accepted by any provider
 	if !currency.IsAccepted() {
-		err = fmt.Errorf("currency [%s] is not accepted by all providers at this time", currency.Name())
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not accepted by all providers at this time", currency.Name())
 		return
 	}
 
-	// Loop providers and get a rate
-	for _, provider := range c.Providers() {
-		providerUsed = provider
-		switch provider {
-		case ProviderCoinPaprika:
-			var response *TickerResponse
-			if response, err = c.CoinPaprika().GetMarketPrice(ctx, CoinPaprikaQuoteID); err == nil && response != nil {
-				rate = response.Quotes.USD.Price
-			}
-		case ProviderWhatsOnChain:
-			var response *whatsonchain.ExchangeRate
-			if response, err = c.WhatsOnChain().GetExchangeRate(ctx); err == nil && response != nil {
-				rate = response.Rate
-			}
-		case providerLast:
-			err = fmt.Errorf("provider unknown")
-			return
+	// Consensus rules are enabled (use the median of the agreeing providers)
+	if c.options.useConsensus() {
+		var aggregated *AggregatedRate
+		if aggregated, err = c.GetAggregatedRate(ctx, currency, AggregationMedian); err == nil {
+			providerUsed = aggregated.Contributors[0].Provider
+			result = aggregated.rateResult()
+			status = result.Cache
 		}
+		return
+	}
 
-		// todo: log the error for sanity in case the user want's to see the failure?
+	// Only use the providers that can quote the currency
+	providers := c.providersFor(currency)
+	if len(providers) == 0 {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not supported by any provider", currency.Name())
+		return
+	}
 
-		// Did we get a rate? Otherwise, keep looping
-		if rate > 0 {
-			return
-		}
+	// Request the providers in order (every failure is recorded). If HedgeDelay is set,
+	// the next provider is also requested while a slow provider has not answered
+	attempts := make([]providerAttempt, len(providers))
+	winner, failed := hedge(ctx, c.options.HedgeDelay, len(providers), func(ctx context.Context, index int) error {
+		provider, attempt := providers[index], &attempts[index]
+		start := time.Now()
+		c.logAttempt(ctx, "rate", provider, currency, index+1)
+		attemptCtx, attemptSpan := c.startAttempt(ctx, provider, currency, index)
+		attempt.quote, attempt.status, attempt.err = c.getProviderQuote(attemptCtx, provider, currency)
+		attempt.latency = time.Since(start)
+		endAttempt(attemptSpan, attempt.status, attempt.err)
+		return attempt.err
+	})
+
+	// Record the failures (in the order they failed)
+	var errs ProviderErrors
+	for _, index := range failed {
+		attempt := &attempts[index]
+		providerUsed, status = providerType(providers[index]), attempt.status
+		errs = append(errs, c.providerFailed(
+			ctx, "rate", providers[index], currency, attempt.err, attempt.latency, index < len(providers)-1,
+		))
+	}
+
+	// All the providers failed
+	if winner < 0 {
+		c.logAllFailed(ctx, "rate", currency, errs)
+		err = errs
+		return
 	}
 
+	// Found a rate
+	provider, attempt := providers[winner], &attempts[winner]
+	providerUsed, status = providerType(provider), attempt.status
+	c.logResult(ctx, "rate", provider, currency, len(errs), attempt.latency)
+	result = &RateResult{
+		Ask:          decimal.NewFromFloat(attempt.quote.Ask),
+		Bid:          decimal.NewFromFloat(attempt.quote.Bid),
+		Cache:        status,
+		Currency:     currency,
+		Errors:       errs,
+		FetchedAt:    attempt.quote.FetchedAt,
+		Provider:     providerUsed,
+		ProviderName: provider.Name(),
+		QuotedAt:     attempt.quote.QuotedAt,
+		Rate:         decimal.NewFromFloat(attempt.quote.Rate),
+		Volume24h:    decimal.NewFromFloat(attempt.quote.Volume24h),
+	}
 	return
 }
-
-// todo: create a new method to get all three and then average the results
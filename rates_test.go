@@ -2,27 +2,37 @@ package bsvrates
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mrz1836/go-whatsonchain"
 	"github.com/stretchr/testify/assert"
 )
 
-// newMockClient returns a client for mocking
-func newMockClient(wocClient whatsonchain.ChainService, paprikaClient CoinPaprikaInterface,
+// newMockClient returns a client for mocking (the default options are used if nil)
+func newMockClient(options *ClientOptions, wocClient whatsonchain.ChainService, paprikaClient CoinPaprikaInterface,
 	providers ...Provider) ClientInterface {
-	client := NewClient(nil, nil, providers...)
+	client := NewClient(options, nil, providers...)
 	client.SetWhatsOnChain(wocClient)
 	client.SetCoinPaprika(paprikaClient)
 	return client
 }
 
+// newMockOptions returns the default options using only the given custom providers
+func newMockOptions(providers ...RateProvider) *ClientOptions {
+	options := DefaultClientOptions()
+	options.CustomProviders = providers
+	return options
+}
+
 // TestClient_GetRate will test the method GetRate()
 func TestClient_GetRate(t *testing.T) {
 	// t.Parallel()
 
 	t.Run("valid get rate - default", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
 		assert.NotNil(t, client)
 
 		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
@@ -33,7 +43,7 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("valid get rate - whats on chain", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
 		assert.NotNil(t, client)
 
 		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
@@ -44,7 +54,7 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("valid get rate - custom providers", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika, ProviderWhatsOnChain)
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderCoinPaprika, ProviderWhatsOnChain)
 		assert.NotNil(t, client)
 
 		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
@@ -55,7 +65,7 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("non accepted currency", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		_, _, rateErr := client.GetRate(context.Background(), 123)
@@ -63,7 +73,7 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("failed rate - default", func(t *testing.T) {
-		client := newMockClient(&mockWOCValid{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
@@ -74,7 +84,7 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("failed rate - whats on chain", func(t *testing.T) {
-		client := newMockClient(&mockWOCFailed{}, &mockPaprikaValid{})
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaValid{})
 		assert.NotNil(t, client)
 
 		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
@@ -85,11 +95,247 @@ func TestClient_GetRate(t *testing.T) {
 	})
 
 	t.Run("failed rate - all providers", func(t *testing.T) {
-		client := newMockClient(&mockWOCFailed{}, &mockPaprikaFailed{})
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
 		assert.NotNil(t, client)
 
 		rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
 		assert.Error(t, err)
 		assert.Equal(t, float64(0), rate)
 	})
+
+	t.Run("multi fiat - routes to coin paprika", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain, ProviderCoinPaprika)
+		assert.NotNil(t, client)
+
+		rate, provider, err := client.GetRate(context.Background(), CurrencyEuro)
+		assert.NoError(t, err)
+		assert.Equal(t, 145.12, rate)
+		assert.Equal(t, "CoinPaprika", provider.Name())
+	})
+
+	t.Run("multi fiat - no provider supports the currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{}, ProviderWhatsOnChain)
+		assert.NotNil(t, client)
+
+		rate, _, err := client.GetRate(context.Background(), CurrencyEuro)
+		assert.Error(t, err)
+		assert.Equal(t, float64(0), rate)
+	})
+
+	t.Run("bitcoin is not accepted", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		_, _, err := client.GetRate(context.Background(), CurrencyBitcoin)
+		assert.Error(t, err)
+	})
+}
+
+// TestClient_GetRateResult will test the method GetRateResult()
+func TestClient_GetRateResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid rate - quote time from coin paprika", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "158.49415248", result.Rate.String())
+		assert.Equal(t, Currency(CurrencyDollars), result.Currency)
+		assert.Equal(t, ProviderCoinPaprika, result.Provider)
+		assert.Equal(t, "CoinPaprika", result.ProviderName)
+		assert.Equal(t, CacheDisabled, result.Cache)
+		assert.Equal(t, time.Date(2020, 7, 1, 18, 36, 56, 0, time.UTC), result.QuotedAt)
+		assert.False(t, result.FetchedAt.IsZero())
+		assert.Empty(t, result.Errors)
+	})
+
+	t.Run("fail-over - errors are recorded", func(t *testing.T) {
+		client := newMockClient(newMockOptions(
+			&mockRateProvider{name: "down"},
+			&mockRateProvider{name: "in-house", rate: 150},
+		), nil, nil)
+		assert.NotNil(t, client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "150", result.Rate.String())
+		assert.Equal(t, "in-house", result.ProviderName)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, "down", result.Errors[0].Name)
+			assert.Equal(t, ProviderCustom, result.Errors[0].Provider)
+			assert.Error(t, result.Errors[0].Err)
+		}
+	})
+
+	t.Run("multi fiat - price converter quote time", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyEuro)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "145.12", result.Rate.String())
+		assert.Equal(t, time.Date(2020, 7, 1, 22, 3, 14, 0, time.UTC), result.QuotedAt)
+	})
+
+	t.Run("custom provider - no quote time", func(t *testing.T) {
+		client := newMockClient(newMockOptions(&mockRateProvider{name: "in-house", rate: 150}), nil, nil)
+		assert.NotNil(t, client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, ProviderCustom, result.Provider)
+		assert.Equal(t, "in-house", result.ProviderName)
+		assert.True(t, result.QuotedAt.IsZero())
+		assert.False(t, result.FetchedAt.IsZero())
+	})
+
+	t.Run("consensus - oldest quote time", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.MinimumQuorum = 2
+		client := NewClient(options, nil, ProviderCoinPaprika, ProviderWhatsOnChain)
+		client.SetCoinPaprika(&mockPaprikaValid{})
+		client.SetWhatsOnChain(&mockWOCValid{})
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, time.Date(2020, 7, 1, 18, 36, 56, 0, time.UTC), result.QuotedAt)
+		assert.Empty(t, result.Errors)
+	})
+
+	t.Run("failed rate - all providers", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaFailed{})
+		assert.NotNil(t, client)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+// TestClient_GetRate_MaxRateAge will test rejecting stale quotes
+func TestClient_GetRate_MaxRateAge(t *testing.T) {
+	t.Parallel()
+
+	// newMaxAgeClient returns a client with a maximum rate age
+	newMaxAgeClient := func(maxAge time.Duration, providers ...Provider) ClientInterface {
+		options := DefaultClientOptions()
+		options.MaxRateAge = maxAge
+		client := NewClient(options, nil, providers...)
+		client.SetWhatsOnChain(&mockWOCValid{})
+		client.SetCoinPaprika(&mockPaprikaValid{})
+		return client
+	}
+
+	t.Run("stale quote - fail-over to the next provider", func(t *testing.T) {
+		client := newMaxAgeClient(time.Hour)
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, ProviderWhatsOnChain, result.Provider)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, ProviderCoinPaprika, result.Errors[0].Provider)
+			assert.True(t, errors.Is(result.Errors[0], ErrStaleQuote))
+		}
+	})
+
+	t.Run("stale quote - nothing fresh available", func(t *testing.T) {
+		client := newMaxAgeClient(time.Hour, ProviderCoinPaprika)
+
+		rate, provider, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrStaleQuote))
+		assert.Equal(t, float64(0), rate)
+		assert.Equal(t, ProviderCoinPaprika, provider)
+	})
+
+	t.Run("stale quote - conversion", func(t *testing.T) {
+		client := newMaxAgeClient(time.Hour, ProviderCoinPaprika)
+
+		satoshis, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrStaleQuote))
+		assert.Equal(t, int64(0), satoshis)
+	})
+
+	t.Run("fresh quote - conversion", func(t *testing.T) {
+		client := newMaxAgeClient(time.Hour, ProviderWhatsOnChain)
+
+		satoshis, provider, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(628892), satoshis)
+		assert.Equal(t, ProviderWhatsOnChain, provider)
+	})
+
+	t.Run("disabled - any age", func(t *testing.T) {
+		client := newMaxAgeClient(0, ProviderCoinPaprika)
+
+		rate, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.Equal(t, 158.49415248, rate)
+	})
+}
+
+// TestClient_GetRate_ProviderErrors will test the errors collected during fail-over
+func TestClient_GetRate_ProviderErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate limited - fail-over records the status code", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		result, err := client.GetRateResult(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, ProviderWhatsOnChain, result.Provider)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, ProviderCoinPaprika, result.Errors[0].Provider)
+			assert.Equal(t, http.StatusTooManyRequests, result.Errors[0].StatusCode)
+			assert.True(t, result.Errors[0].Latency >= 0)
+		}
+	})
+
+	t.Run("all providers failed - every error is returned", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaRateLimited{})
+
+		_, provider, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.Error(t, err)
+		assert.Equal(t, ProviderWhatsOnChain, provider)
+
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) && assert.Equal(t, 2, len(errs)) {
+			assert.Equal(t, ProviderCoinPaprika, errs[0].Provider)
+			assert.Equal(t, http.StatusTooManyRequests, errs[0].StatusCode)
+			assert.Equal(t, ProviderWhatsOnChain, errs[1].Provider)
+			assert.Equal(t, 0, errs[1].StatusCode)
+		}
+	})
+
+	t.Run("conversion - fail-over errors are attached", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		result, err := client.GetConversionDetails(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, ProviderWhatsOnChain, result.Provider)
+		if assert.Equal(t, 1, len(result.Errors)) {
+			assert.Equal(t, http.StatusTooManyRequests, result.Errors[0].StatusCode)
+		}
+	})
+
+	t.Run("conversion - all providers failed", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCFailed{}, &mockPaprikaRateLimited{})
+
+		_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		var errs ProviderErrors
+		if assert.True(t, errors.As(err, &errs)) {
+			assert.Equal(t, 2, len(errs))
+		}
+	})
 }
@@ -0,0 +1,148 @@
+package bsvrates
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// basisPoints is the number of basis points in 100%
+const basisPoints = 10000
+
+// QuoteSide is the side of the quote used for a conversion (see GetSideConversion)
+type QuoteSide uint8
+
+// QuoteSide constants for the different sides of a quote.
+// Leave the start and last constants in place
+const (
+	SideMid QuoteSide = iota // 0 (the provider rate, IE: the last price or an aggregator average, no spread)
+
+	SideBid  // 1 (buying BSV back from a customer, the spread lowers the rate)
+	SideAsk  // 2 (selling BSV to a customer, the spread raises the rate)
+	sideLast // 3
+)
+
+// Name will return the display name for the given quote side
+func (s QuoteSide) Name() string {
+	switch s {
+	case SideMid:
+		return "mid"
+	case SideBid:
+		return "bid"
+	case SideAsk:
+		return "ask"
+	case sideLast:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// IsValid will return true if the quote side is known
+func (s QuoteSide) IsValid() bool {
+	return s < sideLast
+}
+
+// checkSide will return an error (ErrInvalidRate) if the side is not valid, the spread is out of range,
+// or a spread is used with the mid
+func checkSide(side QuoteSide, spreadBps float64) error {
+	if !side.IsValid() {
+		return newKindError(ErrInvalidRate, "quote side [%d] is not valid", side)
+	} else if spreadBps < 0 || spreadBps >= basisPoints {
+		return newKindError(ErrInvalidRate, "spread of %v basis points must be between 0 and %d", spreadBps, basisPoints)
+	} else if side == SideMid && spreadBps != 0 {
+		return newKindError(ErrInvalidRate, "spread cannot be applied to the %s rate (use the bid or ask)", side.Name())
+	}
+	return nil
+}
+
+// SideRate will return the BSV->Currency rate for the side of the quote, including the spread
+// (in basis points, IE: 50 is 0.5%). The bid and ask fall back to the rate if the provider
+// does not supply them. The spread raises the ask and lowers the bid, and the mid has no
+// direction so a spread with the mid is rejected (ErrInvalidRate)
+func SideRate(quote *Quote, side QuoteSide, spreadBps float64) (rate float64, err error) {
+
+	// Check the side and spread
+	if err = checkSide(side, spreadBps); err != nil {
+		return 0, err
+	} else if quote == nil || quote.Rate <= 0 {
+		return 0, newKindError(ErrInvalidRate, "quote rate must be a positive value")
+	}
+
+	// Start with the side of the quote (or the rate if the provider does not supply it)
+	sideRate, spread := decimal.NewFromFloat(quote.Rate), decimal.NewFromFloat(spreadBps).Div(decimal.NewFromInt(basisPoints))
+	switch side {
+	case SideBid:
+		if quote.Bid > 0 {
+			sideRate = decimal.NewFromFloat(quote.Bid)
+		}
+		sideRate = sideRate.Mul(decimal.NewFromInt(1).Sub(spread))
+	case SideAsk:
+		if quote.Ask > 0 {
+			sideRate = decimal.NewFromFloat(quote.Ask)
+		}
+		sideRate = sideRate.Mul(decimal.NewFromInt(1).Add(spread))
+	case SideMid, sideLast:
+	}
+	rate, _ = sideRate.Round(8).Float64()
+	return
+}
+
+// ConvertPriceToSatoshisForSide is the same as ConvertPriceToSatoshis but rounds in favor of the
+// side: the ask rounds down (fewer satoshis are sold) and the mid and bid round up
+func ConvertPriceToSatoshisForSide(rate float64, amount float64, side QuoteSide) (satoshis int64, err error) {
+	if side != SideAsk {
+		return ConvertPriceToSatoshis(rate, amount)
+	} else if amount == 0 {
+		return 0, newKindError(ErrInvalidAmount, "an amount must be set")
+	} else if rate <= 0 {
+		return 0, newKindError(ErrInvalidRate, "current rate must be a positive value")
+	}
+
+	// => 1e8 * amount / rate (rounded down to whole satoshis)
+	return decimal.NewFromInt(SatoshisPerBitcoin).Mul(
+		decimal.NewFromFloat(amount),
+	).Div(decimal.NewFromFloat(rate)).Floor().IntPart(), nil
+}
+
+// GetSideConversion will get the satoshi amount for the given currency + amount using the
+// side of the quote (bid or ask, where the provider supplies it) plus the spread in basis points.
+//
+// Use SideAsk when selling BSV to a customer and SideBid when buying it back. The result
+// includes the effective rate used (see SideRate, a spread with SideMid is rejected)
+func (c *Client) GetSideConversion(ctx context.Context, currency Currency, amount float64,
+	side QuoteSide, spreadBps float64) (result *ConversionResult, err error) {
+
+	// Check the side and spread (before using a provider request)
+	if err = checkSide(side, spreadBps); err != nil {
+		return
+	}
+
+	// Get the quote (with the bid and ask if the provider supplies them)
+	var rateResult *RateResult
+	if rateResult, _, _, err = c.getRate(ctx, currency); err != nil {
+		return
+	}
+	quote := &Quote{}
+	quote.Ask, _ = rateResult.Ask.Float64()
+	quote.Bid, _ = rateResult.Bid.Float64()
+	quote.Rate, _ = rateResult.Rate.Float64()
+
+	// Convert using the side of the quote
+	result = &ConversionResult{
+		Amount:       amount,
+		Cache:        rateResult.Cache,
+		Currency:     currency,
+		Errors:       rateResult.Errors,
+		Provider:     rateResult.Provider,
+		ProviderName: rateResult.ProviderName,
+		Side:         side,
+		SpreadBps:    spreadBps,
+	}
+	if result.Rate, err = SideRate(quote, side, spreadBps); err != nil {
+		return nil, err
+	} else if result.Satoshis, err = ConvertPriceToSatoshisForSide(result.Rate, amount, side); err != nil {
+		return nil, err
+	}
+	return
+}
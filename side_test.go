@@ -0,0 +1,187 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQuoteSide_Name will test the method Name()
+func TestQuoteSide_Name(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name          string
+		side          QuoteSide
+		expectedName  string
+		expectedValid bool
+	}{
+		{"SideMid", SideMid, "mid", true},
+		{"SideBid", SideBid, "bid", true},
+		{"SideAsk", SideAsk, "ask", true},
+		{"side 3", 3, "", false},
+		{"side 4", 4, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedName, test.side.Name())
+			assert.Equal(t, test.expectedValid, test.side.IsValid())
+		})
+	}
+}
+
+// TestSideRate will test the method SideRate()
+func TestSideRate(t *testing.T) {
+	t.Parallel()
+
+	exchange := &Quote{Ask: 150.5, Bid: 149.5, Rate: 150}
+	aggregator := &Quote{Rate: 150}
+
+	var tests = []struct {
+		name         string
+		quote        *Quote
+		side         QuoteSide
+		spreadBps    float64
+		expectedRate float64
+		expectedErr  bool
+	}{
+		{"mid", exchange, SideMid, 0, 150, false},
+		{"mid with a spread", exchange, SideMid, 50, 0, true},
+		{"bid", exchange, SideBid, 0, 149.5, false},
+		{"ask", exchange, SideAsk, 0, 150.5, false},
+		{"bid with spread", exchange, SideBid, 50, 148.7525, false},
+		{"ask with spread", exchange, SideAsk, 50, 151.2525, false},
+		{"bid falls back to the rate", aggregator, SideBid, 50, 149.25, false},
+		{"ask falls back to the rate", aggregator, SideAsk, 50, 150.75, false},
+		{"invalid side", exchange, sideLast, 0, 0, true},
+		{"negative spread", exchange, SideAsk, -1, 0, true},
+		{"spread too large", exchange, SideBid, 10000, 0, true},
+		{"no quote", nil, SideMid, 0, 0, true},
+		{"no rate", &Quote{Ask: 150.5}, SideAsk, 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rate, err := SideRate(test.quote, test.side, test.spreadBps)
+			assert.Equal(t, test.expectedRate, rate)
+			if test.expectedErr {
+				assert.True(t, errors.Is(err, ErrInvalidRate))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestConvertPriceToSatoshisForSide will test the method ConvertPriceToSatoshisForSide()
+func TestConvertPriceToSatoshisForSide(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		rate             float64
+		amount           float64
+		side             QuoteSide
+		expectedSatoshis int64
+		expectedErr      bool
+	}{
+		{150, 1, SideMid, 666667, false},
+		{150, 1, SideBid, 666667, false},
+		{150, 1, SideAsk, 666666, false},
+		{150, 0, SideAsk, 0, true},
+		{0, 1, SideAsk, 0, true},
+		{-150, 1, SideAsk, 0, true},
+	}
+	for _, test := range tests {
+		satoshis, err := ConvertPriceToSatoshisForSide(test.rate, test.amount, test.side)
+		assert.Equal(t, test.expectedSatoshis, satoshis)
+		assert.Equal(t, test.expectedErr, err != nil)
+	}
+}
+
+// TestClient_GetSideConversion will test the method GetSideConversion()
+func TestClient_GetSideConversion(t *testing.T) {
+	t.Parallel()
+
+	server := newMockBitfinexServer()
+	defer server.Close()
+
+	t.Run("exchange ask with spread", func(t *testing.T) {
+		client := NewClient(nil, nil, ProviderBitfinex)
+		client.SetBitfinex(newMockBitfinexClient(server))
+
+		result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideAsk, 50)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderBitfinex, result.Provider)
+		assert.Equal(t, SideAsk, result.Side)
+		assert.Equal(t, float64(50), result.SpreadBps)
+		assert.Equal(t, 48.35055, result.Rate)
+		assert.Equal(t, int64(2068228), result.Satoshis)
+	})
+
+	t.Run("exchange bid with spread", func(t *testing.T) {
+		client := NewClient(nil, nil, ProviderBitfinex)
+		client.SetBitfinex(newMockBitfinexClient(server))
+
+		result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideBid, 50)
+		assert.NoError(t, err)
+		assert.Equal(t, 47.80975, result.Rate)
+		assert.Equal(t, int64(2091624), result.Satoshis)
+	})
+
+	t.Run("provider without bid and ask", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "in-house", rate: 150}}
+		client := NewClient(options, nil)
+
+		result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideAsk, 50)
+		assert.NoError(t, err)
+		assert.Equal(t, "in-house", result.ProviderName)
+		assert.Equal(t, 150.75, result.Rate)
+		assert.Equal(t, int64(663349), result.Satoshis)
+	})
+
+	t.Run("invalid side or spread - no provider request", func(t *testing.T) {
+		provider := &mockRateProvider{name: "in-house", rate: 150}
+		client := NewClient(newMockOptions(provider), nil)
+
+		var tests = []struct {
+			side      QuoteSide
+			spreadBps float64
+		}{
+			{SideAsk, -5},
+			{SideBid, basisPoints},
+			{sideLast, 0},
+			{SideMid, 50},
+		}
+		for _, test := range tests {
+			result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, test.side, test.spreadBps)
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, ErrInvalidRate))
+		}
+		assert.Equal(t, int64(0), provider.rateCalls())
+	})
+
+	t.Run("spread with the mid", func(t *testing.T) {
+		client := newMockClient(newMockOptions(&mockRateProvider{name: "in-house", rate: 150}), nil, nil)
+
+		result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideMid, 50)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrInvalidRate))
+
+		result, err = client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideMid, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(0), result.SpreadBps)
+		assert.Equal(t, int64(666667), result.Satoshis)
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.CustomProviders = []RateProvider{&mockRateProvider{name: "down"}}
+		client := NewClient(options, nil)
+
+		result, err := client.GetSideConversion(context.Background(), CurrencyDollars, 1, SideBid, 0)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
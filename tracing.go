@@ -0,0 +1,81 @@
+package bsvrates
+
+import "context"
+
+// Span names
+const (
+	spanAggregatedRate  = "bsvrates.GetAggregatedRate"
+	spanConversion      = "bsvrates.GetConversion"
+	spanProviderRequest = "bsvrates.ProviderRequest"
+	spanRate            = "bsvrates.GetRate"
+)
+
+// Attribute is a key/value attribute for a span
+type Attribute struct {
+	Key   string      `json:"key"`   // Name of the attribute (IE: provider)
+	Value interface{} `json:"value"` // Value of the attribute (string, int, float64 or bool)
+}
+
+// Span is a unit of work started by a Tracer
+type Span interface {
+	End(err error)
+	SetAttributes(attributes ...Attribute)
+}
+
+// Tracer is the interface for starting spans (see the README for an OpenTelemetry adapter).
+// The returned context is passed to the providers, so their requests are children of the span
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// nopTracer is the default Tracer (no spans)
+type nopTracer struct{}
+
+// Start returns the context and a span that does nothing
+func (nopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+// nopSpan is a span that does nothing
+type nopSpan struct{}
+
+// End does nothing
+func (nopSpan) End(error) {}
+
+// SetAttributes does nothing
+func (nopSpan) SetAttributes(...Attribute) {}
+
+// tracer will return the configured tracer (defaults to no spans)
+func (c *ClientOptions) tracer() Tracer {
+	if c.Tracer == nil {
+		return nopTracer{}
+	}
+	return c.Tracer
+}
+
+// startAttempt will start the span for a provider attempt (child of the lookup span in the context)
+func (c *Client) startAttempt(ctx context.Context, provider RateProvider, currency Currency,
+	fallbackIndex int) (context.Context, Span) {
+	return c.tracer.Start(ctx, spanProviderRequest,
+		Attribute{Key: "provider", Value: provider.Name()},
+		Attribute{Key: "currency", Value: currency.Name()},
+		Attribute{Key: "fallback_index", Value: fallbackIndex},
+	)
+}
+
+// endAttempt will end the span for a provider attempt (with the cache status and HTTP status if known)
+func endAttempt(span Span, status CacheStatus, err error) {
+	span.SetAttributes(Attribute{Key: "cache", Value: status.Name()})
+	if statusCode := errorStatusCode(err); statusCode > 0 {
+		span.SetAttributes(Attribute{Key: "status_code", Value: statusCode})
+	}
+	span.End(err)
+}
+
+// endLookup will end the span for a rate or conversion lookup (with the provider used)
+func endLookup(span Span, providerUsed Provider, err error) {
+	if err == nil {
+		span.SetAttributes(Attribute{Key: "provider", Value: providerUsed.Name()})
+	}
+	span.End(err)
+}
@@ -0,0 +1,109 @@
+package bsvrates
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClient_Tracer will test the spans started by the client
+func TestClient_Tracer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate - span per attempt", func(t *testing.T) {
+		tracer := &mockTracer{}
+		options := DefaultClientOptions()
+		options.Tracer = tracer
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		lookups := tracer.named(spanRate)
+		if !assert.Equal(t, 1, len(lookups)) {
+			return
+		}
+		assert.True(t, lookups[0].ended)
+		assert.NoError(t, lookups[0].err)
+		assert.Equal(t, "usd", lookups[0].attributes["currency"])
+		assert.Equal(t, "WhatsOnChain", lookups[0].attributes["provider"])
+
+		attempts := tracer.named(spanProviderRequest)
+		if assert.Equal(t, 2, len(attempts)) {
+			assert.Equal(t, lookups[0], attempts[0].parent)
+			assert.Equal(t, "CoinPaprika", attempts[0].attributes["provider"])
+			assert.Equal(t, 0, attempts[0].attributes["fallback_index"])
+			assert.Equal(t, http.StatusTooManyRequests, attempts[0].attributes["status_code"])
+			assert.Error(t, attempts[0].err)
+
+			assert.Equal(t, lookups[0], attempts[1].parent)
+			assert.Equal(t, "WhatsOnChain", attempts[1].attributes["provider"])
+			assert.Equal(t, 1, attempts[1].attributes["fallback_index"])
+			assert.Nil(t, attempts[1].attributes["status_code"])
+			assert.NoError(t, attempts[1].err)
+		}
+	})
+
+	t.Run("rate - failed lookup", func(t *testing.T) {
+		tracer := &mockTracer{}
+		options := DefaultClientOptions()
+		options.Tracer = tracer
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaRateLimited{})
+
+		_, _, err := client.GetRate(context.Background(), CurrencyBitcoin)
+		assert.Error(t, err)
+
+		lookups := tracer.named(spanRate)
+		if assert.Equal(t, 1, len(lookups)) {
+			assert.True(t, lookups[0].ended)
+			assert.Error(t, lookups[0].err)
+			assert.Nil(t, lookups[0].attributes["provider"])
+		}
+		assert.Empty(t, tracer.named(spanProviderRequest))
+	})
+
+	t.Run("conversion - span per attempt", func(t *testing.T) {
+		tracer := &mockTracer{}
+		options := DefaultClientOptions()
+		options.Tracer = tracer
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		_, _, err := client.GetConversion(context.Background(), CurrencyDollars, 1)
+		assert.NoError(t, err)
+
+		lookups := tracer.named(spanConversion)
+		if assert.Equal(t, 1, len(lookups)) {
+			assert.Equal(t, float64(1), lookups[0].attributes["amount"])
+			assert.Equal(t, "CoinPaprika", lookups[0].attributes["provider"])
+		}
+		attempts := tracer.named(spanProviderRequest)
+		if assert.Equal(t, 1, len(attempts)) {
+			assert.Equal(t, lookups[0], attempts[0].parent)
+			assert.Equal(t, "disabled", attempts[0].attributes["cache"])
+		}
+	})
+
+	t.Run("consensus - aggregated span is a child of the lookup", func(t *testing.T) {
+		tracer := &mockTracer{}
+		options := DefaultClientOptions()
+		options.MinimumQuorum = 2
+		options.Tracer = tracer
+		client := newMockClient(options, &mockWOCValid{}, &mockPaprikaValid{})
+
+		_, _, err := client.GetRate(context.Background(), CurrencyDollars)
+		assert.NoError(t, err)
+
+		lookups := tracer.named(spanRate)
+		aggregated := tracer.named(spanAggregatedRate)
+		if assert.Equal(t, 1, len(lookups)) && assert.Equal(t, 1, len(aggregated)) {
+			assert.Equal(t, lookups[0], aggregated[0].parent)
+			assert.Equal(t, 2, aggregated[0].attributes["contributors"])
+			assert.Equal(t, "median", aggregated[0].attributes["method"])
+		}
+		for _, attempt := range tracer.named(spanProviderRequest) {
+			assert.Equal(t, aggregated[0], attempt.parent)
+		}
+	})
+}
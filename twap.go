@@ -0,0 +1,170 @@
+package bsvrates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxTWAPTicks is the most historical tickers requested for a TWAP (a day of 5 minute tickers)
+const maxTWAPTicks = 288
+
+// twapIntervals are the historical ticker intervals that can be used for a TWAP (smallest first)
+var twapIntervals = []struct {
+	duration time.Duration
+	interval tickerInterval
+}{
+	{5 * time.Minute, TickerInterval5m},
+	{10 * time.Minute, TickerInterval10m},
+	{15 * time.Minute, TickerInterval15m},
+	{30 * time.Minute, TickerInterval30m},
+	{45 * time.Minute, TickerInterval45m},
+	{time.Hour, TickerInterval1h},
+	{2 * time.Hour, TickerInterval2h},
+	{3 * time.Hour, TickerInterval3h},
+	{6 * time.Hour, TickerInterval6h},
+	{12 * time.Hour, TickerInterval12h},
+	{24 * time.Hour, TickerInterval24h},
+	{7 * 24 * time.Hour, TickerInterval7d},
+	{14 * 24 * time.Hour, TickerInterval14d},
+	{30 * 24 * time.Hour, TickerInterval30d},
+	{90 * 24 * time.Hour, TickerInterval90d},
+	{365 * 24 * time.Hour, TickerInterval365d},
+}
+
+// TWAPResult is the time-weighted average price over a window of time
+type TWAPResult struct {
+	Currency Currency       `json:"currency"` // Currency of the rate
+	End      time.Time      `json:"end"`      // End of the window
+	Interval tickerInterval `json:"interval"` // Interval of the historical tickers used
+	Rate     float64        `json:"rate"`     // Time-weighted average BSV->Currency rate
+	Start    time.Time      `json:"start"`    // Start of the window
+	Ticks    int            `json:"ticks"`    // Number of historical tickers returned
+}
+
+// TWAPInterval will return the smallest historical ticker interval that covers the window
+// in at most 288 tickers (IE: the last 1h uses 5m tickers, the last 7d uses 45m tickers)
+func TWAPInterval(window time.Duration) tickerInterval {
+	for _, entry := range twapIntervals {
+		if window <= entry.duration*maxTWAPTicks {
+			return entry.interval
+		}
+	}
+	return twapIntervals[len(twapIntervals)-1].interval
+}
+
+// twapIntervalDuration will return the duration of the interval (zero if unknown)
+func twapIntervalDuration(interval tickerInterval) time.Duration {
+	for _, entry := range twapIntervals {
+		if entry.interval == interval {
+			return entry.duration
+		}
+	}
+	return 0
+}
+
+// TWAP will return the time-weighted average price of the tickers between the start and end.
+// Each price is held until the next ticker (the ticker before the start sets the opening price),
+// and tickers without a valid timestamp or price are skipped
+func (h HistoricalResults) TWAP(start, end time.Time) (twap float64, err error) {
+
+	// Check the window
+	if !start.Before(end) {
+		err = fmt.Errorf("start time must be before end time")
+		return
+	}
+
+	// Sort the valid tickers by time
+	type tick struct {
+		at    time.Time
+		price float64
+	}
+	ticks := make([]tick, 0, len(h))
+	for _, ticker := range h {
+		if ticker == nil || ticker.Price <= 0 {
+			continue
+		} else if at := parseQuoteTime(ticker.Timestamp); !at.IsZero() {
+			ticks = append(ticks, tick{at: at, price: ticker.Price})
+		}
+	}
+	sort.SliceStable(ticks, func(i, j int) bool {
+		return ticks[i].at.Before(ticks[j].at)
+	})
+
+	// Weight each price by how long it was held within the window
+	total, held := decimal.Zero, decimal.Zero
+	for index, current := range ticks {
+		from, to := current.at, end
+		if index < len(ticks)-1 {
+			to = ticks[index+1].at
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !from.Before(to) {
+			continue
+		}
+		seconds := decimal.NewFromFloat(to.Sub(from).Seconds())
+		total = total.Add(decimal.NewFromFloat(current.price).Mul(seconds))
+		held = held.Add(seconds)
+	}
+
+	// No prices in the window
+	if held.IsZero() {
+		err = fmt.Errorf("no historical tickers between %s and %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		return
+	}
+	twap, _ = total.Div(held).Round(8).Float64()
+	return
+}
+
+// GetTWAP will get the time-weighted average BSV->Currency price over the last window of time
+// (IE: the last hour) from the Coin Paprika historical tickers (USD only).
+//
+// The interval of the tickers is set by the window (see TWAPInterval), and the window ends at
+// the start of the current interval so calls within the same interval share one cached request
+// if the cache is enabled (see GetHistoricalTickers)
+func (c *Client) GetTWAP(ctx context.Context, currency Currency, window time.Duration) (result *TWAPResult, err error) {
+
+	// Check the currency and window
+	if currency != CurrencyDollars {
+		err = newKindError(ErrUnsupportedCurrency, "currency [%s] is not supported for a TWAP", currency.Name())
+		return
+	} else if window <= 0 {
+		err = fmt.Errorf("window must be a positive duration")
+		return
+	}
+
+	// Request the tickers (starting one interval early for the opening price)
+	interval := TWAPInterval(window)
+	end := time.Now().UTC().Truncate(twapIntervalDuration(interval))
+	start := end.Add(-window)
+	var response *HistoricalResponse
+	if response, err = c.GetHistoricalTickers(
+		ctx, CoinPaprikaQuoteID, start.Add(-twapIntervalDuration(interval)), end, 0, TickerQuoteUSD, interval,
+	); err != nil {
+		return
+	} else if response == nil {
+		err = fmt.Errorf("no historical tickers returned from %s", ProviderCoinPaprika.Name())
+		return
+	}
+
+	// Calculate the TWAP
+	result = &TWAPResult{
+		Currency: currency,
+		End:      end,
+		Interval: interval,
+		Start:    start,
+		Ticks:    len(response.Results),
+	}
+	if result.Rate, err = response.Results.TWAP(start, end); err != nil {
+		result = nil
+	}
+	return
+}
@@ -0,0 +1,164 @@
+package bsvrates
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPaprikaTWAP for mocking historical requests (a ticker every interval, rising by 1 each time)
+type mockPaprikaTWAP struct {
+	mockPaprikaValid
+	calls    int64          // Number of historical requests (use atomic)
+	interval tickerInterval // Interval of the last request
+	quote    tickerQuote    // Quote of the last request
+}
+
+// GetHistoricalTickers is a mock response
+func (m *mockPaprikaTWAP) GetHistoricalTickers(_ context.Context, _ string, start, end time.Time, _ int,
+	quote tickerQuote, interval tickerInterval) (response *HistoricalResponse, err error) {
+	atomic.AddInt64(&m.calls, 1)
+	m.interval, m.quote = interval, quote
+	response = &HistoricalResponse{}
+	price := float64(100)
+	for at := start; !at.After(end); at = at.Add(twapIntervalDuration(interval)) {
+		response.Results = append(response.Results, &HistoricalTicker{Price: price, Timestamp: at.Format(time.RFC3339)})
+		price++
+	}
+	return
+}
+
+// TestTWAPInterval will test the method TWAPInterval()
+func TestTWAPInterval(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		window           time.Duration
+		expectedInterval tickerInterval
+	}{
+		{time.Minute, TickerInterval5m},
+		{time.Hour, TickerInterval5m},
+		{24 * time.Hour, TickerInterval5m},
+		{25 * time.Hour, TickerInterval10m},
+		{7 * 24 * time.Hour, TickerInterval45m},
+		{30 * 24 * time.Hour, TickerInterval3h},
+		{365 * 24 * time.Hour, TickerInterval7d},
+		{290 * 365 * 24 * time.Hour, TickerInterval365d},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expectedInterval, TWAPInterval(test.window), test.window.String())
+	}
+}
+
+// TestHistoricalResults_TWAP will test the method TWAP()
+func TestHistoricalResults_TWAP(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := HistoricalResults{
+		{Price: 110, Timestamp: "2021-01-01T00:10:00Z"},
+		{Price: 100, Timestamp: "2021-01-01T00:00:00Z"},
+		{Price: 120, Timestamp: "2021-01-01T00:40:00Z"},
+		{Price: 500, Timestamp: "not-a-time"},
+		{Price: 0, Timestamp: "2021-01-01T00:20:00Z"},
+		nil,
+	}
+
+	var tests = []struct {
+		name         string
+		results      HistoricalResults
+		start        time.Time
+		end          time.Time
+		expectedTWAP float64
+		expectedErr  bool
+	}{
+		{"full window", results, start, start.Add(time.Hour), 111.66666667, false},
+		{"price before the start is held", results, start.Add(5 * time.Minute), start.Add(15 * time.Minute), 105, false},
+		{"last price is held to the end", results, start.Add(50 * time.Minute), start.Add(2 * time.Hour), 120, false},
+		{"window starts at the first ticker", results, start.Add(-time.Hour), start.Add(30 * time.Minute), 106.66666667, false},
+		{"no tickers in the window", results, start.Add(-2 * time.Hour), start.Add(-time.Hour), 0, true},
+		{"no tickers", nil, start, start.Add(time.Hour), 0, true},
+		{"start after the end", results, start.Add(time.Hour), start, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			twap, err := test.results.TWAP(test.start, test.end)
+			assert.Equal(t, test.expectedTWAP, twap)
+			assert.Equal(t, test.expectedErr, err != nil)
+		})
+	}
+}
+
+// TestClient_GetTWAP will test the method GetTWAP()
+func TestClient_GetTWAP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("last hour", func(t *testing.T) {
+		paprika := &mockPaprikaTWAP{}
+		client := newMockClient(nil, &mockWOCValid{}, paprika)
+
+		result, err := client.GetTWAP(context.Background(), CurrencyDollars, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, TickerInterval5m, paprika.interval)
+		assert.Equal(t, TickerQuoteUSD, paprika.quote)
+		assert.Equal(t, Currency(CurrencyDollars), result.Currency)
+		assert.Equal(t, TickerInterval5m, result.Interval)
+		assert.Equal(t, time.Hour, result.End.Sub(result.Start))
+		assert.Equal(t, result.End.Truncate(5*time.Minute), result.End)
+		assert.Equal(t, 14, result.Ticks)
+		assert.Equal(t, 106.5, result.Rate)
+	})
+
+	t.Run("cache enabled - calls share the request", func(t *testing.T) {
+		paprika := &mockPaprikaTWAP{}
+		options := DefaultClientOptions()
+		options.CacheTTL = time.Minute
+		client := NewClient(options, nil)
+		client.SetCoinPaprika(paprika)
+
+		first, err := client.GetTWAP(context.Background(), CurrencyDollars, time.Hour)
+		assert.NoError(t, err)
+		second, err := client.GetTWAP(context.Background(), CurrencyDollars, time.Hour)
+		assert.NoError(t, err)
+		if first.End.Equal(second.End) {
+			assert.Equal(t, first.Rate, second.Rate)
+			assert.Equal(t, int64(1), atomic.LoadInt64(&paprika.calls))
+		}
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaTWAP{})
+
+		result, err := client.GetTWAP(context.Background(), CurrencyEuro, time.Hour)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaTWAP{})
+
+		result, err := client.GetTWAP(context.Background(), CurrencyDollars, 0)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+
+	t.Run("no tickers", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaValid{})
+
+		result, err := client.GetTWAP(context.Background(), CurrencyDollars, time.Hour)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+
+	t.Run("request failed", func(t *testing.T) {
+		client := newMockClient(nil, &mockWOCValid{}, &mockPaprikaFailed{})
+
+		result, err := client.GetTWAP(context.Background(), CurrencyDollars, time.Hour)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}